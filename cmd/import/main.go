@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/juanmaabanto/go-ms-beers/common/database"
+	"github.com/juanmaabanto/go-ms-beers/internal/app/command"
+	"github.com/juanmaabanto/go-ms-beers/internal/infrastructure"
+	"github.com/juanmaabanto/go-ms-beers/internal/validations"
+)
+
+// Imports the beers of a CSV or NDJSON file, as POST /beers/import does. The
+// rows that fail are written to the report file, one JSON object per line.
+func main() {
+	file := flag.String("file", "", "CSV or NDJSON file to import")
+	format := flag.String("format", "", "csv or ndjson, taken from the file extension by default")
+	mode := flag.String("mode", command.ImportInsert, "insert only creates beers, upsert also replaces the existing ones")
+	dryRun := flag.Bool("dry-run", false, "validate and report without writing")
+	mapping := flag.String("map", "", "comma separated column=field pairs renaming the CSV columns")
+	reportPath := flag.String("report", "", "file the failed rows are written to")
+	flag.Parse()
+
+	if *file == "" {
+		log.Fatal("The -file flag is required")
+	}
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+	}
+
+	columns, err := command.ParseColumnMapping(*mapping)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	input, err := os.Open(*file)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer input.Close()
+
+	source, err := command.NewBeerSource(*format, input, columns)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	onError := func(row command.ImportRowError) error {
+		log.Printf("line %d: %s %s", row.Line, row.Status, row.Message)
+		return nil
+	}
+
+	if *reportPath != "" {
+		report, err := os.Create(*reportPath)
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		defer report.Close()
+
+		encoder := json.NewEncoder(report)
+		onError = func(row command.ImportRowError) error {
+			return encoder.Encode(row)
+		}
+	}
+
+	ctx := context.Background()
+	conn := database.NewMongoConnection(ctx, os.Getenv("MONGODB_NAME"), os.Getenv("MONGODB_URI"))
+	handler := command.NewImportBeersHandler(infrastructure.NewBeerRepository(conn), validations.NewValidationUtil())
+
+	result, err := handler.Handle(ctx, command.ImportBeers{
+		Source:  source,
+		Mode:    *mode,
+		DryRun:  *dryRun,
+		OnError: onError,
+	})
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("%d rows read: %d created, %d updated, %d failed (dry run: %t)",
+		result.Rows, result.Created, result.Updated, result.Failed, result.DryRun)
+}
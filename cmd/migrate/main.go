@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/juanmaabanto/go-ms-beers/common/database"
+	"github.com/juanmaabanto/go-ms-beers/internal/infrastructure"
+)
+
+// Converts the beer prices stored as doubles to Decimal128. The service reads
+// both, so it can run before or after the new version is deployed.
+func main() {
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	ctx := context.Background()
+	conn := database.NewMongoConnection(ctx, os.Getenv("MONGODB_NAME"), os.Getenv("MONGODB_URI"))
+
+	migrated, err := infrastructure.MigrateBeerPrices(ctx, conn)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("%d beer prices migrated to Decimal128", migrated)
+}
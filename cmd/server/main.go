@@ -43,9 +43,18 @@ func main() {
 
 type ServerInterface interface {
 	AddBeer(c echo.Context) error
+	CreateBeers(c echo.Context) error
+	ImportBeers(c echo.Context) error
+	ExportBeers(c echo.Context) error
 	GetBeer(c echo.Context) error
 	ListBeer(c echo.Context) error
+	GetBeerFacets(c echo.Context) error
 	GetBoxPrice(c echo.Context) error
+	UpdateBeer(c echo.Context) error
+	PatchBeer(c echo.Context) error
+	DeleteBeer(c echo.Context) error
+	RestoreBeer(c echo.Context) error
+	GetQuote(c echo.Context) error
 }
 
 func Handler(si ServerInterface, router *echo.Echo) {
@@ -57,18 +66,33 @@ func Handler(si ServerInterface, router *echo.Echo) {
 
 	loggerManager := managers.NewLoggerManager("https://logger.mydominio.pe/", "ms-beer")
 
+	logger := middleware.LoggerWithConfig(middleware.LoggerConfig{
+		LoggerErrorFunc: loggerManager.Error,
+	})
+
 	api := router.Group("/beers")
+	api.Use(logger)
 
-	api.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
-		LoggerErrorFunc: loggerManager.Error,
-	}))
+	quotes := router.Group("/quotes")
+	quotes.Use(logger)
 
 	//Swagger
 	router.GET("/*", echoSwagger.WrapHandler)
 
 	//beer
 	api.GET("", si.ListBeer)
+	api.GET("/facets", si.GetBeerFacets)
+	api.GET("/export", si.ExportBeers)
 	api.GET("/:beerId", si.GetBeer)
 	api.POST("", si.AddBeer)
+	api.POST("\\:batch", si.CreateBeers)
+	api.POST("/import", si.ImportBeers)
+	api.PUT("/:beerId", si.UpdateBeer)
+	api.PATCH("/:beerId", si.PatchBeer)
+	api.DELETE("/:beerId", si.DeleteBeer)
+	api.POST("/:beerId/restore", si.RestoreBeer)
 	api.GET("/:beerId/boxprice", si.GetBoxPrice)
+
+	//quote
+	quotes.POST("", si.GetQuote)
 }
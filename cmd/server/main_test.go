@@ -0,0 +1,68 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// testServer answers every route with the name of its handler.
+type testServer struct{}
+
+func named(name string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.String(http.StatusOK, name)
+	}
+}
+
+func (testServer) AddBeer(c echo.Context) error       { return named("AddBeer")(c) }
+func (testServer) CreateBeers(c echo.Context) error   { return named("CreateBeers")(c) }
+func (testServer) ImportBeers(c echo.Context) error   { return named("ImportBeers")(c) }
+func (testServer) ExportBeers(c echo.Context) error   { return named("ExportBeers")(c) }
+func (testServer) GetBeer(c echo.Context) error       { return named("GetBeer")(c) }
+func (testServer) ListBeer(c echo.Context) error      { return named("ListBeer")(c) }
+func (testServer) GetBeerFacets(c echo.Context) error { return named("GetBeerFacets")(c) }
+func (testServer) GetBoxPrice(c echo.Context) error   { return named("GetBoxPrice")(c) }
+func (testServer) UpdateBeer(c echo.Context) error    { return named("UpdateBeer")(c) }
+func (testServer) PatchBeer(c echo.Context) error     { return named("PatchBeer")(c) }
+func (testServer) DeleteBeer(c echo.Context) error    { return named("DeleteBeer")(c) }
+func (testServer) RestoreBeer(c echo.Context) error   { return named("RestoreBeer")(c) }
+func (testServer) GetQuote(c echo.Context) error      { return named("GetQuote")(c) }
+
+func Test_Handler_Routes(t *testing.T) {
+	router := echo.New()
+	Handler(testServer{}, router)
+
+	tests := []struct {
+		method  string
+		path    string
+		status  int
+		handler string
+	}{
+		{method: http.MethodPost, path: "/beers:batch", status: http.StatusOK, handler: "CreateBeers"},
+		{method: http.MethodPost, path: "/beers", status: http.StatusOK, handler: "AddBeer"},
+		{method: http.MethodPost, path: "/beers/import", status: http.StatusOK, handler: "ImportBeers"},
+		{method: http.MethodPost, path: "/beers/7/restore", status: http.StatusOK, handler: "RestoreBeer"},
+		{method: http.MethodGet, path: "/beers/export", status: http.StatusOK, handler: "ExportBeers"},
+		{method: http.MethodGet, path: "/beers/7", status: http.StatusOK, handler: "GetBeer"},
+		// Only the GET swagger catch-all matches it.
+		{method: http.MethodPost, path: "/beers:other", status: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			request := httptest.NewRequest(tt.method, tt.path, nil)
+			recorder := httptest.NewRecorder()
+
+			router.ServeHTTP(recorder, request)
+
+			assert.Equal(t, tt.status, recorder.Code)
+
+			if tt.handler != "" {
+				assert.Equal(t, tt.handler, recorder.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ErrInvalid is returned for tokens that were altered or signed with another
+// secret.
+var ErrInvalid = errors.New("invalid cursor")
+
+// Cursor marks the edge of a page. Values holds the sort values of the last
+// document of the page, or of the first one when Backward is set.
+type Cursor struct {
+	Sort     string        `bson:"s"`
+	Values   []interface{} `bson:"v"`
+	Backward bool          `bson:"b,omitempty"`
+}
+
+// Codec turns cursors into opaque tokens signed with HMAC-SHA256, so clients
+// cannot forge a position.
+type Codec struct {
+	secret []byte
+}
+
+func NewCodec(secret []byte) Codec {
+	if len(secret) == 0 {
+		panic("empty secret")
+	}
+
+	return Codec{secret: secret}
+}
+
+func (c Codec) Encode(cursor Cursor) (string, error) {
+	payload, err := bson.Marshal(cursor)
+
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(c.sign(payload)), nil
+}
+
+func (c Codec) Decode(token string) (Cursor, error) {
+	cursor := Cursor{}
+	parts := strings.Split(token, ".")
+
+	if len(parts) != 2 {
+		return cursor, ErrInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+
+	if err != nil {
+		return cursor, ErrInvalid
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+
+	if err != nil || !hmac.Equal(signature, c.sign(payload)) {
+		return cursor, ErrInvalid
+	}
+
+	if err := bson.Unmarshal(payload, &cursor); err != nil {
+		return Cursor{}, ErrInvalid
+	}
+
+	return cursor, nil
+}
+
+func (c Codec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,45 @@
+package cursor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func Test_NewCodec(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("panic")
+		}
+	}()
+
+	NewCodec(nil)
+}
+
+func Test_Codec_Round_Trip(t *testing.T) {
+	// Arrange
+	codec := NewCodec([]byte("secret"))
+	price, _ := primitive.ParseDecimal128("12.50")
+
+	// Act
+	token, err := codec.Encode(Cursor{Sort: "price:-1,_id:1", Values: []interface{}{price, int64(7)}, Backward: true})
+	result, decodeErr := codec.Decode(token)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, decodeErr)
+	assert.Equal(t, Cursor{Sort: "price:-1,_id:1", Values: []interface{}{price, int64(7)}, Backward: true}, result)
+}
+
+func Test_Codec_Rejects_Forged_Tokens(t *testing.T) {
+	codec := NewCodec([]byte("secret"))
+	token, _ := codec.Encode(Cursor{Sort: "_id:1", Values: []interface{}{int64(7)}})
+	other, _ := NewCodec([]byte("other")).Encode(Cursor{Sort: "_id:1", Values: []interface{}{int64(7)}})
+
+	for _, forged := range []string{"", "abc", token + "x", "x" + token, other} {
+		_, err := codec.Decode(forged)
+
+		assert.ErrorIs(t, err, ErrInvalid, forged)
+	}
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
@@ -29,3 +30,25 @@ func NewMongoConnection(ctx context.Context, databaseName string, uri string) Mo
 		Database: *client.Database(databaseName),
 	}
 }
+
+// SupportsTransactions reports whether the server is a replica set member or
+// a mongos, the deployments that run multi-document transactions.
+func (c MongoConnection) SupportsTransactions(ctx context.Context) (bool, error) {
+	var reply struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+
+	err := c.Database.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&reply)
+
+	if err != nil {
+		// Servers before 4.4.2 only know the legacy name.
+		err = c.Database.RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&reply)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return reply.SetName != "" || reply.Msg == "isdbgrid", nil
+}
@@ -0,0 +1,324 @@
+package decimal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// Limits of a Decimal128, the largest decimal Parse accepts: the number of
+// significant digits and the range of the exponent of the last digit.
+const (
+	maxDigits   = 34
+	minExponent = -6176
+	maxExponent = 6111
+)
+
+// ErrOutOfRange reports a number that does not fit in a Decimal128.
+var ErrOutOfRange = errors.New("decimal out of range")
+
+// Decimal is an exact base 10 number equal to value * 10^-scale. The zero
+// value is 0.
+type Decimal struct {
+	value *big.Int
+	scale int32
+}
+
+func New(value int64, scale int32) Decimal {
+	d := Decimal{value: big.NewInt(value), scale: scale}
+
+	if scale < 0 {
+		d.value.Mul(d.value, pow10(-scale))
+		d.scale = 0
+	}
+
+	return d
+}
+
+func NewFromInt(value int64) Decimal {
+	return New(value, 0)
+}
+
+// NewFromFloat returns the shortest decimal that converts back to value, so
+// 0.1 becomes exactly 0.1.
+func NewFromFloat(value float64) Decimal {
+	d, err := Parse(strconv.FormatFloat(value, 'f', -1, 64))
+
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+// Parse reads a number such as "-12.50" or "1.5E+3". Numbers that do not fit
+// in a Decimal128 return ErrOutOfRange, so that a short input cannot expand
+// into billions of digits.
+func Parse(s string) (Decimal, error) {
+	text := s
+	exponent := int64(0)
+
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(text[i+1:], 10, 32)
+
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+
+		exponent = e
+		text = text[:i]
+	}
+
+	scale := int64(0)
+
+	if i := strings.IndexByte(text, '.'); i >= 0 {
+		scale = int64(len(text) - i - 1)
+		text = text[:i] + text[i+1:]
+	}
+
+	digits := strings.TrimLeft(text, "+-")
+
+	if len(digits) == 0 || len(text)-len(digits) > 1 || strings.IndexFunc(digits, isNotDigit) >= 0 {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	scale -= exponent
+
+	if len(strings.TrimLeft(digits, "0")) > maxDigits || -scale < minExponent || -scale > maxExponent {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrOutOfRange, s)
+	}
+
+	value, _ := new(big.Int).SetString(text, 10)
+
+	if scale < 0 {
+		value.Mul(value, pow10(int32(-scale)))
+		scale = 0
+	}
+
+	return Decimal{value: value, scale: int32(scale)}, nil
+}
+
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+
+	return Decimal{value: a.Add(a, b), scale: scale}
+}
+
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+
+	return Decimal{value: a.Sub(a, b), scale: scale}
+}
+
+func (d Decimal) Mul(other Decimal) Decimal {
+	value := new(big.Int).Mul(d.coefficient(), other.coefficient())
+
+	return Decimal{value: value, scale: d.scale + other.scale}
+}
+
+// Div returns d / other rounded half away from zero to scale decimals. It
+// panics when other is zero.
+func (d Decimal) Div(other Decimal, scale int32) Decimal {
+	numerator := new(big.Int).Mul(d.coefficient(), pow10(scale+other.scale))
+	denominator := new(big.Int).Mul(other.coefficient(), pow10(d.scale))
+
+	return Decimal{value: quoRound(numerator, denominator), scale: scale}
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{value: new(big.Int).Neg(d.coefficient()), scale: d.scale}
+}
+
+// Round returns d with exactly scale decimals, rounding half away from zero.
+func (d Decimal) Round(scale int32) Decimal {
+	if scale >= d.scale {
+		value := new(big.Int).Mul(d.coefficient(), pow10(scale-d.scale))
+
+		return Decimal{value: value, scale: scale}
+	}
+
+	return Decimal{value: quoRound(d.coefficient(), pow10(d.scale-scale)), scale: scale}
+}
+
+func (d Decimal) Cmp(other Decimal) int {
+	a, b, _ := align(d, other)
+
+	return a.Cmp(b)
+}
+
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+func (d Decimal) Sign() int {
+	return d.coefficient().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Scale returns the number of decimals of d.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+func (d Decimal) Float64() float64 {
+	value, _ := strconv.ParseFloat(d.String(), 64)
+
+	return value
+}
+
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.coefficient()).String()
+
+	if d.scale > 0 {
+		if pad := int(d.scale) - len(digits) + 1; pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+
+		point := len(digits) - int(d.scale)
+		digits = digits[:point] + "." + digits[point:]
+	}
+
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+
+	return digits
+}
+
+// MarshalJSON writes d as a JSON number with all of its decimals.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a string holding one.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	parsed, err := Parse(string(bytes.Trim(data, `"`)))
+
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+
+	return nil
+}
+
+// MarshalBSONValue stores d as a Decimal128.
+func (d Decimal) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	value, err := primitive.ParseDecimal128(d.String())
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return bsontype.Decimal128, bsoncore.AppendDecimal128(nil, value), nil
+}
+
+// UnmarshalBSONValue reads a Decimal128 and also the doubles and integers
+// that were stored before prices became decimals.
+func (d *Decimal) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	raw := bson.RawValue{Type: t, Value: data}
+
+	switch t {
+	case bsontype.Decimal128:
+		parsed, err := Parse(raw.Decimal128().String())
+
+		if err != nil {
+			return err
+		}
+
+		*d = parsed
+	case bsontype.Double:
+		*d = NewFromFloat(raw.Double())
+	case bsontype.Int32:
+		*d = NewFromInt(int64(raw.Int32()))
+	case bsontype.Int64:
+		*d = NewFromInt(raw.Int64())
+	case bsontype.String:
+		parsed, err := Parse(raw.StringValue())
+
+		if err != nil {
+			return err
+		}
+
+		*d = parsed
+	case bsontype.Null, bsontype.Undefined:
+		*d = Decimal{}
+	default:
+		return fmt.Errorf("cannot decode %s into a decimal", t)
+	}
+
+	return nil
+}
+
+func (d Decimal) coefficient() *big.Int {
+	if d.value == nil {
+		return new(big.Int)
+	}
+
+	return d.value
+}
+
+// align returns copies of the coefficients of a and b at the same scale.
+func align(a Decimal, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := a.scale
+
+	if b.scale > scale {
+		scale = b.scale
+	}
+
+	x := new(big.Int).Mul(a.coefficient(), pow10(scale-a.scale))
+	y := new(big.Int).Mul(b.coefficient(), pow10(scale-b.scale))
+
+	return x, y, scale
+}
+
+// quoRound divides x by y rounding half away from zero.
+func quoRound(x *big.Int, y *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(x, y, new(big.Int))
+
+	remainder.Abs(remainder).Lsh(remainder, 1)
+
+	if remainder.Cmp(new(big.Int).Abs(y)) >= 0 {
+		if x.Sign()*y.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	return quotient
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func isNotDigit(r rune) bool {
+	return r < '0' || r > '9'
+}
@@ -0,0 +1,152 @@
+package decimal
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func Test_Parse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "12", want: "12"},
+		{input: "-12.50", want: "-12.50"},
+		{input: "0.05", want: "0.05"},
+		{input: ".5", want: "0.5"},
+		{input: "1.5E+3", want: "1500"},
+		{input: "15E-3", want: "0.015"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := Parse(tt.input)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, d.String())
+		})
+	}
+}
+
+func Test_Parse_Invalid(t *testing.T) {
+	for _, input := range []string{"", "-", "abc", "1.2.3", "--1", "1e", "NaN"} {
+		_, err := Parse(input)
+
+		assert.Error(t, err, input)
+	}
+}
+
+func Test_Parse_Out_Of_Range(t *testing.T) {
+	for _, input := range []string{"1e2000000000", "1e6112", "1e-6177", "1.00000000000000000000000000000000001"} {
+		_, err := Parse(input)
+
+		assert.ErrorIs(t, err, ErrOutOfRange, input)
+	}
+
+	for _, input := range []string{"1e6111", "1e-6176", "1.000000000000000000000000000000001"} {
+		d, err := Parse(input)
+
+		assert.NoError(t, err, input)
+
+		_, _, err = d.MarshalBSONValue()
+
+		assert.NoError(t, err, input)
+	}
+}
+
+func Test_NewFromFloat(t *testing.T) {
+	assert.Equal(t, "0.1", NewFromFloat(0.1).String())
+	assert.Equal(t, "71.99", NewFromFloat(71.99).String())
+}
+
+func Test_Arithmetic(t *testing.T) {
+	a := MustParse("0.1")
+	b := MustParse("0.2")
+
+	assert.Equal(t, "0.3", a.Add(b).String())
+	assert.Equal(t, "-0.1", a.Sub(b).String())
+	assert.Equal(t, "0.02", a.Mul(b).String())
+	assert.Equal(t, "0.333", NewFromInt(1).Div(NewFromInt(3), 3).String())
+	assert.Equal(t, "0.667", NewFromInt(2).Div(NewFromInt(3), 3).String())
+	assert.Equal(t, "-0.667", NewFromInt(-2).Div(NewFromInt(3), 3).String())
+	assert.True(t, MustParse("1.50").Equal(MustParse("1.5")))
+	assert.Equal(t, 1, MustParse("1.51").Cmp(MustParse("1.5")))
+	assert.True(t, Decimal{}.IsZero())
+}
+
+func Test_Round(t *testing.T) {
+	tests := []struct {
+		input string
+		scale int32
+		want  string
+	}{
+		{input: "71.99999999", scale: 2, want: "72.00"},
+		{input: "2.345", scale: 2, want: "2.35"},
+		{input: "-2.345", scale: 2, want: "-2.35"},
+		{input: "2.344", scale: 2, want: "2.34"},
+		{input: "1565.5", scale: 0, want: "1566"},
+		{input: "10", scale: 2, want: "10.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, MustParse(tt.input).Round(tt.scale).String())
+		})
+	}
+}
+
+func Test_JSON(t *testing.T) {
+	value := struct {
+		Price Decimal `json:"price"`
+	}{}
+
+	err := json.Unmarshal([]byte(`{"price":12.50}`), &value)
+	data, _ := json.Marshal(value)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"price":12.50}`, string(data))
+	assert.NoError(t, json.Unmarshal([]byte(`{"price":"3.1"}`), &value))
+	assert.Equal(t, "3.1", value.Price.String())
+	assert.Error(t, json.Unmarshal([]byte(`{"price":true}`), &value))
+}
+
+func Test_BSON(t *testing.T) {
+	type document struct {
+		Price Decimal `bson:"price"`
+	}
+
+	data, err := bson.Marshal(document{Price: MustParse("71.99")})
+	decoded := document{}
+	decodeErr := bson.Unmarshal(data, &decoded)
+
+	assert.NoError(t, err)
+	assert.NoError(t, decodeErr)
+	assert.Equal(t, bson.TypeDecimal128, bson.Raw(data).Lookup("price").Type)
+	assert.Equal(t, "71.99", decoded.Price.String())
+}
+
+func Test_BSON_Legacy_Types(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{name: "double", value: 71.99, want: "71.99"},
+		{name: "int32", value: int32(10), want: "10"},
+		{name: "int64", value: int64(10), want: "10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := bson.Marshal(bson.M{"price": tt.value})
+			decoded := struct {
+				Price Decimal `bson:"price"`
+			}{}
+
+			err := bson.Unmarshal(data, &decoded)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, decoded.Price.String())
+		})
+	}
+}
@@ -16,4 +16,7 @@ type Document struct {
 	CreatedBy  string             `json:"createdBy" bson:"createdBy"`
 	ModifiedAt *time.Time         `json:"modifiedAt,omitempty" bson:"modifiedAt,omitempty"`
 	ModifiedBy *string            `json:"modifiedBy,omitempty" bson:"modifiedBy,omitempty"`
+	DeletedAt  *time.Time         `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+	DeletedBy  *string            `json:"deletedBy,omitempty" bson:"deletedBy,omitempty"`
+	Version    int64              `json:"version" bson:"version"`
 }
@@ -5,11 +5,15 @@ type ErrorType struct {
 }
 
 var (
-	ErrorTypeUnknown    = ErrorType{"unknown"}
-	ErrorTypeBadRequest = ErrorType{"bad-request"}
-	ErrorTypeConflict   = ErrorType{"conflict"}
-	ErrorTypeNotFound   = ErrorType{"not-found"}
-	ErrorTypeValidation = ErrorType{"Validation Failure"}
+	ErrorTypeUnknown            = ErrorType{"unknown"}
+	ErrorTypeBadGateway         = ErrorType{"bad-gateway"}
+	ErrorTypeBadRequest         = ErrorType{"bad-request"}
+	ErrorTypeConflict           = ErrorType{"conflict"}
+	ErrorTypeForbidden          = ErrorType{"forbidden"}
+	ErrorTypeNotFound           = ErrorType{"not-found"}
+	ErrorTypeValidation         = ErrorType{"Validation Failure"}
+	ErrorTypePreconditionFailed = ErrorType{"precondition-failed"}
+	ErrorTypeServiceUnavailable = ErrorType{"service-unavailable"}
 )
 
 type ApplicationError struct {
@@ -43,6 +47,14 @@ func NewApplicationError(err string, title string) ApplicationError {
 	}
 }
 
+func NewBadGatewayError(err string) ApplicationError {
+	return ApplicationError{
+		message:   err,
+		title:     "Bad Gateway",
+		errorType: ErrorTypeBadGateway,
+	}
+}
+
 func NewBadRequestError(err string) ApplicationError {
 	return ApplicationError{
 		message:   err,
@@ -59,6 +71,14 @@ func NewConflictError(err string) ApplicationError {
 	}
 }
 
+func NewForbiddenError(err string) ApplicationError {
+	return ApplicationError{
+		message:   err,
+		title:     "Forbidden",
+		errorType: ErrorTypeForbidden,
+	}
+}
+
 func NewNotFoundError(err string) ApplicationError {
 	return ApplicationError{
 		message:   err,
@@ -67,6 +87,22 @@ func NewNotFoundError(err string) ApplicationError {
 	}
 }
 
+func NewPreconditionFailedError(err string) ApplicationError {
+	return ApplicationError{
+		message:   err,
+		title:     "Precondition Failed",
+		errorType: ErrorTypePreconditionFailed,
+	}
+}
+
+func NewServiceUnavailableError(err string) ApplicationError {
+	return ApplicationError{
+		message:   err,
+		title:     "Service Unavailable",
+		errorType: ErrorTypeServiceUnavailable,
+	}
+}
+
 func NewValidationError(errors map[string]string) ApplicationError {
 	return ApplicationError{
 		message:   "One or more validation errors occurred.",
@@ -102,7 +102,7 @@ func getMessage(err error, request http.Request) string {
 	}
 
 	switch customErr.ErrorType() {
-	case errors.ErrorTypeBadRequest, errors.ErrorTypeNotFound, errors.ErrorTypeValidation:
+	case errors.ErrorTypeBadGateway, errors.ErrorTypeBadRequest, errors.ErrorTypeForbidden, errors.ErrorTypeNotFound, errors.ErrorTypePreconditionFailed, errors.ErrorTypeServiceUnavailable, errors.ErrorTypeValidation:
 		return customErr.Error()
 	default:
 		return getCustomMessage(request)
@@ -117,12 +117,20 @@ func getStatusCode(err error) int {
 	}
 
 	switch customErr.ErrorType() {
+	case errors.ErrorTypeBadGateway:
+		return http.StatusBadGateway
 	case errors.ErrorTypeBadRequest:
 		return http.StatusBadRequest
 	case errors.ErrorTypeConflict:
 		return http.StatusConflict
+	case errors.ErrorTypeForbidden:
+		return http.StatusForbidden
 	case errors.ErrorTypeNotFound:
 		return http.StatusNotFound
+	case errors.ErrorTypePreconditionFailed:
+		return http.StatusPreconditionFailed
+	case errors.ErrorTypeServiceUnavailable:
+		return http.StatusServiceUnavailable
 	case errors.ErrorTypeValidation:
 		return http.StatusUnprocessableEntity
 	default:
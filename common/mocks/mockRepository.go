@@ -3,67 +3,155 @@ package mocks
 import (
 	"context"
 
+	"github.com/juanmaabanto/go-ms-beers/common"
 	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
-type MockRepository struct {
+type MockRepository[T common.IDocument, ID comparable] struct {
 	mock.Mock
 }
 
-func (mock MockRepository) Count(ctx context.Context, filter interface{}) (int64, error) {
-	args := mock.Called(ctx, filter)
+func (mock *MockRepository[T, ID]) Aggregate(ctx context.Context, filter interface{}, stages mongo.Pipeline, receiver interface{}, opts ...common.ReadOption) error {
+	args := mock.Called(withOptions(opts, ctx, filter, stages, receiver)...)
+
+	return args.Error(0)
+}
+
+func (mock *MockRepository[T, ID]) Count(ctx context.Context, filter interface{}, opts ...common.ReadOption) (int64, error) {
+	args := mock.Called(withOptions(opts, ctx, filter)...)
 	result := args.Get(0)
 
 	return result.(int64), args.Error(1)
 }
 
-func (mock MockRepository) DeleteById(ctx context.Context, id int64) (int64, error) {
-	args := mock.Called(ctx, id)
+func (mock *MockRepository[T, ID]) DeleteById(ctx context.Context, id ID, version int64) (int64, error) {
+	args := mock.Called(ctx, id, version)
 	result := args.Get(0)
 
 	return result.(int64), args.Error(1)
 }
 
-func (mock MockRepository) FilterBy(ctx context.Context, filter interface{}, receiver []interface{}) error {
-	args := mock.Called()
+func (mock *MockRepository[T, ID]) FilterBy(ctx context.Context, filter interface{}, sort interface{}, fn func(T) error, opts ...common.ReadOption) error {
+	args := mock.Called(withOptions(opts, ctx, filter, sort, fn)...)
 
 	return args.Error(0)
 }
 
-func (mock MockRepository) FindById(ctx context.Context, id int64, receiver interface{}) error {
-	args := mock.Called(ctx, id, receiver)
+func (mock *MockRepository[T, ID]) FindById(ctx context.Context, id ID, opts ...common.ReadOption) (T, error) {
+	args := mock.Called(withOptions(opts, ctx, id)...)
 
-	return args.Error(0)
+	return document[T](args.Get(0)), args.Error(1)
 }
 
-func (mock MockRepository) FindOne(ctx context.Context, filter interface{}, receiver interface{}) error {
-	args := mock.Called()
+func (mock *MockRepository[T, ID]) FindOne(ctx context.Context, filter interface{}, opts ...common.ReadOption) (T, error) {
+	args := mock.Called(withOptions(opts, ctx, filter)...)
 
-	return args.Error(0)
+	return document[T](args.Get(0)), args.Error(1)
 }
 
-func (mock MockRepository) InsertMany(ctx context.Context, documents []interface{}) ([]int64, error) {
-	args := mock.Called()
+func (mock *MockRepository[T, ID]) InsertMany(ctx context.Context, documents []T, ordered bool) (common.InsertManyResult, error) {
+	args := mock.Called(ctx, documents, ordered)
 	result := args.Get(0)
 
-	return result.([]int64), args.Error(1)
+	return result.(common.InsertManyResult), args.Error(1)
 }
 
-func (mock MockRepository) InsertOne(ctx context.Context, document interface{}) (int64, error) {
+func (mock *MockRepository[T, ID]) InsertOne(ctx context.Context, document T) (ID, error) {
 	args := mock.Called(ctx, document)
 	result := args.Get(0)
 
+	return result.(ID), args.Error(1)
+}
+
+func (mock *MockRepository[T, ID]) Paginated(ctx context.Context, filter interface{}, sort interface{}, pageSize int64, start int64, opts ...common.ReadOption) ([]T, error) {
+	args := mock.Called(withOptions(opts, ctx, filter, sort, pageSize, start)...)
+
+	return page[T](args.Get(0), opts), args.Error(1)
+}
+
+func (mock *MockRepository[T, ID]) PaginatedByKeyset(ctx context.Context, filter interface{}, sort bson.D, keyset common.Keyset, pageSize int64, opts ...common.ReadOption) ([]T, error) {
+	args := mock.Called(withOptions(opts, ctx, filter, sort, keyset, pageSize)...)
+
+	return page[T](args.Get(0), opts), args.Error(1)
+}
+
+func (mock *MockRepository[T, ID]) RestoreById(ctx context.Context, id ID, restoredBy string) (int64, error) {
+	args := mock.Called(ctx, id, restoredBy)
+	result := args.Get(0)
+
 	return result.(int64), args.Error(1)
 }
 
-func (mock MockRepository) Paginated(ctx context.Context, filter interface{}, sort interface{}, pageSize int64, start int64, receiver interface{}) error {
-	args := mock.Called(ctx, filter, sort, pageSize, start, receiver)
+func (mock *MockRepository[T, ID]) SoftDeleteById(ctx context.Context, id ID, version int64, deletedBy string) (int64, error) {
+	args := mock.Called(ctx, id, version, deletedBy)
+	result := args.Get(0)
 
-	return args.Error(0)
+	return result.(int64), args.Error(1)
 }
 
-func (mock MockRepository) UpdateOne(ctx context.Context, id int64, document interface{}) error {
-	args := mock.Called(ctx, id, document)
+func (mock *MockRepository[T, ID]) UpdateOne(ctx context.Context, id ID, version int64, document T) error {
+	args := mock.Called(ctx, id, version, document)
 
 	return args.Error(0)
 }
+
+func (mock *MockRepository[T, ID]) UpsertMany(ctx context.Context, documents []T, modifiedBy string) (common.UpsertManyResult, error) {
+	args := mock.Called(ctx, documents, modifiedBy)
+	result := args.Get(0)
+
+	return result.(common.UpsertManyResult), args.Error(1)
+}
+
+// withOptions appends the read options to the recorded arguments only when the
+// caller passed some, so expectations without options keep matching.
+func withOptions(opts []common.ReadOption, arguments ...interface{}) []interface{} {
+	if len(opts) > 0 {
+		arguments = append(arguments, opts)
+	}
+
+	return arguments
+}
+
+// document returns the document of an expectation, or the zero T when it
+// returns nil, as the single-document reads do with ErrNotFound.
+func document[T common.IDocument](result interface{}) T {
+	var empty T
+
+	if result == nil {
+		return empty
+	}
+
+	return result.(T)
+}
+
+// page returns the documents of an expectation and, as the repository does
+// for WithRaw, appends them marshalled to the raw documents.
+func page[T common.IDocument](result interface{}, opts []common.ReadOption) []T {
+	documents := []T{}
+
+	if result != nil {
+		documents = result.([]T)
+	}
+
+	options := common.ReadOptions{}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.Raw != nil {
+		for _, item := range documents {
+			raw, err := bson.Marshal(item)
+
+			if err != nil {
+				panic(err)
+			}
+
+			*options.Raw = append(*options.Raw, raw)
+		}
+	}
+
+	return documents
+}
@@ -2,126 +2,742 @@ package common
 
 import (
 	"context"
+	errorsN "errors"
+	"fmt"
+	"time"
 
 	"github.com/juanmaabanto/go-ms-beers/common/database"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-type IBaseRepository interface {
-	Count(ctx context.Context, filter interface{}) (int64, error)
-	DeleteById(ctx context.Context, id int64) (int64, error)
-	FilterBy(ctx context.Context, filter interface{}, receiver []interface{}) error
-	FindById(ctx context.Context, id int64, receiver interface{}) error
-	FindOne(ctx context.Context, filter interface{}, receiver interface{}) error
-	InsertMany(ctx context.Context, documents []interface{}) ([]int64, error)
-	InsertOne(ctx context.Context, document interface{}) (int64, error)
-	Paginated(ctx context.Context, filter interface{}, sort interface{}, pageSize int64, start int64, receiver interface{}) error
-	UpdateOne(ctx context.Context, id int64, document interface{}) error
+// IRepository reads and writes the documents of type T, whose _id is of type
+// ID. The reads that return documents decode them into T.
+type IRepository[T IDocument, ID comparable] interface {
+	Aggregate(ctx context.Context, filter interface{}, stages mongo.Pipeline, receiver interface{}, opts ...ReadOption) error
+	Count(ctx context.Context, filter interface{}, opts ...ReadOption) (int64, error)
+	DeleteById(ctx context.Context, id ID, version int64) (int64, error)
+	FilterBy(ctx context.Context, filter interface{}, sort interface{}, fn func(T) error, opts ...ReadOption) error
+	FindById(ctx context.Context, id ID, opts ...ReadOption) (T, error)
+	FindOne(ctx context.Context, filter interface{}, opts ...ReadOption) (T, error)
+	InsertMany(ctx context.Context, documents []T, ordered bool) (InsertManyResult, error)
+	InsertOne(ctx context.Context, document T) (ID, error)
+	Paginated(ctx context.Context, filter interface{}, sort interface{}, pageSize int64, start int64, opts ...ReadOption) ([]T, error)
+	PaginatedByKeyset(ctx context.Context, filter interface{}, sort bson.D, keyset Keyset, pageSize int64, opts ...ReadOption) ([]T, error)
+	RestoreById(ctx context.Context, id ID, restoredBy string) (int64, error)
+	SoftDeleteById(ctx context.Context, id ID, version int64, deletedBy string) (int64, error)
+	UpdateOne(ctx context.Context, id ID, version int64, document T) error
+	UpsertMany(ctx context.Context, documents []T, modifiedBy string) (UpsertManyResult, error)
 }
 
-type BaseRepository struct {
+var (
+	// ErrNotFound reports a single-document read that matched no document.
+	ErrNotFound = errorsN.New("not found")
+	// ErrDuplicateKey reports a document whose _id or unique key is taken.
+	ErrDuplicateKey = errorsN.New("duplicate key")
+	// ErrNotInserted reports a document of an ordered InsertMany that was not
+	// tried because an earlier one failed.
+	ErrNotInserted = errorsN.New("not inserted")
+)
+
+// AnyVersion makes a write that expects a version apply to any version.
+const AnyVersion int64 = -1
+
+// InsertManyResult holds what happened to every document of an InsertMany, in
+// the order of the batch. Errors[i] is nil when the document was inserted.
+type InsertManyResult struct {
+	Errors []error
+}
+
+// UpsertManyResult holds what happened to every document of an UpsertMany, in
+// the order of the batch. Errors[i] is nil when the document was written and
+// Inserted[i] tells whether it was new.
+type UpsertManyResult struct {
+	Errors   []error
+	Inserted []bool
+}
+
+// ReadOptions holds the settings applied by the read methods of Repository.
+type ReadOptions struct {
+	BatchSize      int32
+	IncludeDeleted bool
+	Projection     interface{}
+	Raw            *[]bson.Raw
+}
+
+type ReadOption func(*ReadOptions)
+
+// WithBatchSize sets how many documents a streaming read fetches from the
+// server at a time, which bounds the documents it holds in memory.
+func WithBatchSize(size int32) ReadOption {
+	return func(o *ReadOptions) {
+		o.BatchSize = size
+	}
+}
+
+// IncludeDeleted makes a read also return soft deleted documents.
+func IncludeDeleted() ReadOption {
+	return func(o *ReadOptions) {
+		o.IncludeDeleted = true
+	}
+}
+
+// Keyset places a page right after the document whose sort values are Values,
+// or right before it when Backward is set. Without values the page starts at
+// the first document.
+type Keyset struct {
+	Values   []interface{}
+	Backward bool
+}
+
+// WithProjection makes a read return only the fields of projection, so the
+// other ones are not read at all.
+func WithProjection(projection interface{}) ReadOption {
+	return func(o *ReadOptions) {
+		o.Projection = projection
+	}
+}
+
+// WithRaw makes a page read also append the documents as stored to raw, in the
+// order of the results, for callers that need the values before decoding.
+func WithRaw(raw *[]bson.Raw) ReadOption {
+	return func(o *ReadOptions) {
+		o.Raw = raw
+	}
+}
+
+type Repository[T IDocument, ID comparable] struct {
 	collection mongo.Collection
 }
 
-func NewBaseRepository(connection database.MongoConnection, document IDocument) BaseRepository {
-	repository := BaseRepository{
+// NewRepository opens the collection named by the GetCollectionName of T.
+func NewRepository[T IDocument, ID comparable](connection database.MongoConnection) Repository[T, ID] {
+	var document T
+
+	repository := Repository[T, ID]{
 		collection: *connection.Database.Collection(document.GetCollectionName()),
 	}
 
 	return repository
 }
 
-func (repo BaseRepository) Count(ctx context.Context, filter interface{}) (int64, error) {
-	result, err := repo.collection.CountDocuments(ctx, filter)
+// Aggregate runs stages on the documents matching filter and decodes the
+// results into receiver. The filter is the first stage, so it may hold a $text
+// search.
+func (repo Repository[T, ID]) Aggregate(ctx context.Context, filter interface{}, stages mongo.Pipeline, receiver interface{}, opts ...ReadOption) error {
+	match := scopeFilter(filter, opts)
+
+	if match == nil {
+		match = bson.D{}
+	}
+
+	pipeline := mongo.Pipeline{bson.D{{Key: "$match", Value: match}}}
+
+	cursor, err := repo.collection.Aggregate(ctx, append(pipeline, stages...))
+
+	if err != nil {
+		return err
+	}
+
+	return cursor.All(ctx, receiver)
+}
+
+func (repo Repository[T, ID]) Count(ctx context.Context, filter interface{}, opts ...ReadOption) (int64, error) {
+	result, err := repo.collection.CountDocuments(ctx, scopeFilter(filter, opts))
 
 	return result, err
 }
 
-func (repo BaseRepository) DeleteById(ctx context.Context, id int64) (int64, error) {
-	result, err := repo.collection.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}})
+// CreateIndexes creates the indexes of the collection. Indexes that already
+// exist with the same definition are left as they are.
+func (repo Repository[T, ID]) CreateIndexes(ctx context.Context, models ...mongo.IndexModel) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	_, err := repo.collection.Indexes().CreateMany(ctx, models)
 
-	return result.DeletedCount, err
+	return err
 }
 
-func (repo BaseRepository) FilterBy(ctx context.Context, filter interface{}, receiver []interface{}) error {
-	cursor, err := repo.collection.Find(ctx, filter)
+// DeleteById removes the document for good only if its stored version is the
+// expected one, or whatever its version with AnyVersion. It returns the number
+// of documents removed.
+func (repo Repository[T, ID]) DeleteById(ctx context.Context, id ID, version int64) (int64, error) {
+	filter := bson.D{{Key: "_id", Value: id}}
+
+	if version != AnyVersion {
+		filter = append(filter, bson.E{Key: "version", Value: versionFilter(version)})
+	}
+
+	result, err := repo.collection.DeleteOne(ctx, filter)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
+
+// FilterBy passes the documents matching filter to fn one at a time, in the
+// order of sort, so whole collections are read holding a single batch in
+// memory. It stops at the first error of fn or when ctx is done, and the
+// cursor is closed however it ends.
+func (repo Repository[T, ID]) FilterBy(ctx context.Context, filter interface{}, sort interface{}, fn func(T) error, opts ...ReadOption) error {
+	settings := readOptions(opts)
+	options := options.Find()
+
+	options.SetSort(sort)
+	options.SetProjection(settings.Projection)
+
+	if settings.BatchSize > 0 {
+		options.SetBatchSize(settings.BatchSize)
+	}
+
+	cursor, err := repo.collection.Find(ctx, scopeFilter(filter, opts), options)
 
 	if err != nil {
 		return err
 	}
 
-	cursor.Decode(receiver)
+	defer cursor.Close(context.Background())
 
-	return nil
-}
+	for cursor.Next(ctx) {
+		var document T
+
+		if err := cursor.Decode(&document); err != nil {
+			return err
+		}
 
-func (repo BaseRepository) FindById(ctx context.Context, id int64, receiver interface{}) error {
-	coll := repo.collection
-	result := coll.FindOne(ctx, bson.D{{Key: "_id", Value: id}})
+		if err := fn(document); err != nil {
+			return err
+		}
 
-	if result.Err() != nil && result.Err() != mongo.ErrNoDocuments {
-		return result.Err()
+		// A batch already fetched is read without asking ctx.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 	}
 
+	return cursor.Err()
+}
+
+// FindById returns the document with the id, or ErrNotFound when there is
+// none.
+func (repo Repository[T, ID]) FindById(ctx context.Context, id ID, opts ...ReadOption) (T, error) {
+	return repo.FindOne(ctx, bson.D{{Key: "_id", Value: id}}, opts...)
+}
+
+// FindOne returns the first document matching filter, or ErrNotFound when
+// there is none.
+func (repo Repository[T, ID]) FindOne(ctx context.Context, filter interface{}, opts ...ReadOption) (T, error) {
+	var document T
+
+	result := repo.collection.FindOne(ctx, scopeFilter(filter, opts), findOneOptions(opts))
+
 	if result.Err() == mongo.ErrNoDocuments {
-		return nil
+		return document, ErrNotFound
 	}
 
-	return result.Decode(receiver)
+	if result.Err() != nil {
+		return document, result.Err()
+	}
+
+	err := result.Decode(&document)
+
+	return document, err
 }
 
-func (repo BaseRepository) FindOne(ctx context.Context, filter interface{}, receiver interface{}) error {
-	result := repo.collection.FindOne(ctx, filter)
+// InsertMany inserts the documents in one round trip. Ordered inserts stop at
+// the first failure, unordered ones try every document. A failure of a single
+// document is reported in the result, the error is kept for failures of the
+// whole batch.
+func (repo Repository[T, ID]) InsertMany(ctx context.Context, documents []T, ordered bool) (InsertManyResult, error) {
+	result := InsertManyResult{Errors: make([]error, len(documents))}
 
-	if result.Err() != nil && result.Err() != mongo.ErrNoDocuments {
-		return result.Err()
+	if len(documents) == 0 {
+		return result, nil
 	}
 
-	return result.Decode(receiver)
-}
+	items := make([]interface{}, len(documents))
 
-func (repo BaseRepository) InsertMany(ctx context.Context, documents []interface{}) ([]int64, error) {
-	result, err := repo.collection.InsertMany(ctx, documents)
+	for i, document := range documents {
+		items[i] = document
+	}
 
-	if err != nil {
-		panic(err)
+	_, err := repo.collection.InsertMany(ctx, items, options.InsertMany().SetOrdered(ordered))
+
+	if err == nil {
+		return result, nil
+	}
+
+	exception, ok := err.(mongo.BulkWriteException)
+
+	if !ok || exception.WriteConcernError != nil {
+		return result, err
 	}
 
-	array := []int64{}
+	failed := len(documents)
+
+	for _, writeError := range exception.WriteErrors {
+		if writeError.Index < 0 || writeError.Index >= len(documents) {
+			return result, err
+		}
+
+		if isDuplicateKey(writeError.Code) {
+			result.Errors[writeError.Index] = fmt.Errorf("%w: %s", ErrDuplicateKey, writeError.Message)
+		} else {
+			result.Errors[writeError.Index] = errorsN.New(writeError.Message)
+		}
 
-	for i := range result.InsertedIDs {
-		array = append(array, result.InsertedIDs[i].(int64))
+		if writeError.Index < failed {
+			failed = writeError.Index
+		}
 	}
 
-	return array, err
+	if ordered {
+		for i := failed + 1; i < len(documents); i++ {
+			result.Errors[i] = ErrNotInserted
+		}
+	}
+
+	return result, nil
 }
 
-func (repo BaseRepository) InsertOne(ctx context.Context, document interface{}) (int64, error) {
+// InsertOne inserts the document and returns its id. A taken id is reported as
+// ErrDuplicateKey. It fails instead of panicking when the stored id is not an
+// ID.
+func (repo Repository[T, ID]) InsertOne(ctx context.Context, document T) (ID, error) {
+	var id ID
+
 	result, err := repo.collection.InsertOne(ctx, document)
 
-	return result.InsertedID.(int64), err
+	if mongo.IsDuplicateKeyError(err) {
+		return id, fmt.Errorf("%w: %s", ErrDuplicateKey, err)
+	}
+
+	if err != nil {
+		return id, err
+	}
+
+	id, ok := result.InsertedID.(ID)
+
+	if !ok {
+		return id, fmt.Errorf("the inserted id %v is a %T, not a %T", result.InsertedID, result.InsertedID, id)
+	}
+
+	return id, nil
 }
 
-func (repo BaseRepository) Paginated(ctx context.Context, filter interface{}, sort interface{}, pageSize int64, start int64, receiver interface{}) error {
+func (repo Repository[T, ID]) Paginated(ctx context.Context, filter interface{}, sort interface{}, pageSize int64, start int64, opts ...ReadOption) ([]T, error) {
 	options := options.Find()
 
 	options.SetSort(sort)
 	options.SetSkip(start)
 	options.SetLimit(pageSize)
+	options.SetProjection(readOptions(opts).Projection)
 
+	return repo.find(ctx, scopeFilter(filter, opts), options, readOptions(opts).Raw)
+}
+
+// PaginatedByKeyset reads the page next to a keyset instead of skipping
+// documents, so deep pages cost the same as the first one and concurrent
+// inserts do not shift them. The sort must end with a unique field such as
+// _id. The page is returned in sort order also when reading backward.
+func (repo Repository[T, ID]) PaginatedByKeyset(ctx context.Context, filter interface{}, sort bson.D, keyset Keyset, pageSize int64, opts ...ReadOption) ([]T, error) {
+	order := sort
+
+	if keyset.Backward {
+		order = reverseSort(sort)
+	}
+
+	if len(keyset.Values) > 0 {
+		seek, err := keysetFilter(sort, keyset)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if filter == nil {
+			filter = seek
+		} else {
+			filter = bson.D{{Key: "$and", Value: bson.A{filter, seek}}}
+		}
+	}
+
+	options := options.Find()
+
+	options.SetSort(order)
+	options.SetLimit(pageSize)
+	options.SetProjection(readOptions(opts).Projection)
+
+	var raw []bson.Raw
+
+	documents, err := repo.find(ctx, scopeFilter(filter, opts), options, &raw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if keyset.Backward {
+		reverse(documents)
+		reverse(raw)
+	}
+
+	if target := readOptions(opts).Raw; target != nil {
+		*target = append(*target, raw...)
+	}
+
+	return documents, nil
+}
+
+// find decodes the documents of a query into T, appending them as stored to
+// raw when it is not nil.
+func (repo Repository[T, ID]) find(ctx context.Context, filter interface{}, options *options.FindOptions, raw *[]bson.Raw) ([]T, error) {
 	cursor, err := repo.collection.Find(ctx, filter, options)
 
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close(context.Background())
+
+	documents := []T{}
+
+	for cursor.Next(ctx) {
+		var document T
+
+		if err := cursor.Decode(&document); err != nil {
+			return nil, err
+		}
+
+		documents = append(documents, document)
+
+		if raw != nil {
+			*raw = append(*raw, append(bson.Raw(nil), cursor.Current...))
+		}
+	}
+
+	return documents, cursor.Err()
+}
+
+// RestoreById clears the deletion mark of a soft deleted document and returns
+// the number of restored documents.
+func (repo Repository[T, ID]) RestoreById(ctx context.Context, id ID, restoredBy string) (int64, error) {
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "deletedAt", Value: bson.D{{Key: "$ne", Value: nil}}},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "modifiedAt", Value: time.Now()},
+			{Key: "modifiedBy", Value: restoredBy},
+		}},
+		{Key: "$unset", Value: bson.D{
+			{Key: "deletedAt", Value: ""},
+			{Key: "deletedBy", Value: ""},
+		}},
+		{Key: "$inc", Value: bson.D{{Key: "version", Value: 1}}},
+	}
+
+	result, err := repo.collection.UpdateOne(ctx, filter, update)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// SoftDeleteById marks a document as deleted without removing it and returns
+// the number of marked documents. Nothing is marked when the stored version is
+// not the expected one.
+func (repo Repository[T, ID]) SoftDeleteById(ctx context.Context, id ID, version int64, deletedBy string) (int64, error) {
+	filter := scopeFilter(bson.D{
+		{Key: "_id", Value: id},
+		{Key: "version", Value: versionFilter(version)},
+	}, nil)
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "deletedAt", Value: time.Now()},
+			{Key: "deletedBy", Value: deletedBy},
+		}},
+		{Key: "$inc", Value: bson.D{{Key: "version", Value: 1}}},
+	}
+
+	result, err := repo.collection.UpdateOne(ctx, filter, update)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// UpdateOne replaces the fields of the document only if its stored version is
+// the expected one, and increments that version. It returns a conflict error
+// when another writer got there first.
+func (repo Repository[T, ID]) UpdateOne(ctx context.Context, id ID, version int64, document T) error {
+	data, err := bson.Marshal(document)
+
 	if err != nil {
 		return err
 	}
 
-	return cursor.All(ctx, receiver)
+	fields := bson.M{}
+
+	if err := bson.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	delete(fields, "_id")
+	delete(fields, "version")
+
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "version", Value: versionFilter(version)},
+	}
+	update := bson.D{
+		{Key: "$set", Value: fields},
+		{Key: "$inc", Value: bson.D{{Key: "version", Value: 1}}},
+	}
+
+	result, err := repo.collection.UpdateOne(ctx, filter, update)
+
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		count, err := repo.collection.CountDocuments(ctx, bson.D{{Key: "_id", Value: id}})
+
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			return errors.NewNotFoundError("document")
+		}
+
+		return errors.NewConflictError("The document was modified by another request.")
+	}
+
+	return nil
 }
 
-func (repo BaseRepository) UpdateOne(ctx context.Context, id int64, document interface{}) error {
-	_, err := repo.collection.UpdateOne(ctx, bson.D{{Key: "_id", Value: id}}, bson.M{"$set": document})
+// isDuplicateKey reports whether a write error code is a unique index
+// violation.
+func isDuplicateKey(code int) bool {
+	return code == 11000 || code == 11001 || code == 12582
+}
 
-	return err
+// UpsertMany writes the documents in one unordered round trip. Existing
+// documents get the new fields and a new version but keep their creation
+// fields, missing ones are inserted. Soft deleted documents are left alone and
+// reported as ErrDuplicateKey. A failure of a single document is reported in
+// the result, the error is kept for failures of the whole batch.
+func (repo Repository[T, ID]) UpsertMany(ctx context.Context, documents []T, modifiedBy string) (UpsertManyResult, error) {
+	result := UpsertManyResult{Errors: make([]error, len(documents)), Inserted: make([]bool, len(documents))}
+
+	if len(documents) == 0 {
+		return result, nil
+	}
+
+	models := []mongo.WriteModel{}
+	now := time.Now()
+
+	for _, document := range documents {
+		model, err := upsertModel(document, modifiedBy, now)
+
+		if err != nil {
+			return result, err
+		}
+
+		models = append(models, model)
+	}
+
+	written, err := repo.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+
+	if written != nil {
+		for index := range written.UpsertedIDs {
+			if index >= 0 && int(index) < len(documents) {
+				result.Inserted[index] = true
+			}
+		}
+	}
+
+	if err == nil {
+		return result, nil
+	}
+
+	exception, ok := err.(mongo.BulkWriteException)
+
+	if !ok || exception.WriteConcernError != nil {
+		return result, err
+	}
+
+	for _, writeError := range exception.WriteErrors {
+		if writeError.Index < 0 || writeError.Index >= len(documents) {
+			return result, err
+		}
+
+		if isDuplicateKey(writeError.Code) {
+			result.Errors[writeError.Index] = fmt.Errorf("%w: %s", ErrDuplicateKey, writeError.Message)
+		} else {
+			result.Errors[writeError.Index] = errorsN.New(writeError.Message)
+		}
+	}
+
+	return result, nil
+}
+
+// upsertModel builds the write of a document for UpsertMany. The filter skips
+// soft deleted documents, so the upsert then tries to insert the same _id and
+// fails with a duplicate key instead of updating a document no read returns.
+func upsertModel(document interface{}, modifiedBy string, now time.Time) (*mongo.UpdateOneModel, error) {
+	data, err := bson.Marshal(document)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fields := bson.M{}
+
+	if err := bson.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	id := fields["_id"]
+	onInsert := bson.M{"createdAt": fields["createdAt"], "createdBy": fields["createdBy"]}
+
+	for _, key := range []string{"_id", "version", "createdAt", "createdBy", "deletedAt", "deletedBy"} {
+		delete(fields, key)
+	}
+
+	fields["modifiedAt"] = now
+	fields["modifiedBy"] = modifiedBy
+
+	return mongo.NewUpdateOneModel().
+		SetFilter(bson.D{{Key: "_id", Value: id}, {Key: "deletedAt", Value: nil}}).
+		SetUpdate(bson.D{
+			{Key: "$set", Value: fields},
+			{Key: "$setOnInsert", Value: onInsert},
+			{Key: "$inc", Value: bson.D{{Key: "version", Value: 1}}},
+		}).
+		SetUpsert(true), nil
+}
+
+// versionFilter matches the expected version. Documents written before
+// versioning existed have no version field and count as version 0.
+func versionFilter(version int64) interface{} {
+	if version == 0 {
+		return bson.D{{Key: "$in", Value: bson.A{0, nil}}}
+	}
+
+	return version
+}
+
+// keysetFilter matches the documents that come after the keyset in the sort,
+// or before it when reading backward: those beyond it on the first field, or
+// equal on the first field and beyond it on the second, and so on. Missing
+// fields sort as null, before every other value.
+func keysetFilter(sort bson.D, keyset Keyset) (bson.D, error) {
+	if len(keyset.Values) != len(sort) {
+		return nil, fmt.Errorf("the keyset has %d values for %d sort fields", len(keyset.Values), len(sort))
+	}
+
+	branches := bson.A{}
+
+	for i, field := range sort {
+		branch := bson.D{}
+
+		for j := 0; j < i; j++ {
+			branch = append(branch, bson.E{Key: sort[j].Key, Value: keyset.Values[j]})
+		}
+
+		greater := !isDescending(field) != keyset.Backward
+		value := keyset.Values[i]
+
+		switch {
+		case greater && value == nil:
+			branch = append(branch, bson.E{Key: field.Key, Value: bson.D{{Key: "$ne", Value: nil}}})
+		case greater:
+			branch = append(branch, bson.E{Key: field.Key, Value: bson.D{{Key: "$gt", Value: value}}})
+		case value == nil:
+			continue
+		default:
+			branch = append(branch, bson.E{Key: "$or", Value: bson.A{
+				bson.D{{Key: field.Key, Value: bson.D{{Key: "$lt", Value: value}}}},
+				bson.D{{Key: field.Key, Value: nil}},
+			}})
+		}
+
+		branches = append(branches, branch)
+	}
+
+	if len(branches) == 0 {
+		return bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: bson.A{}}}}}, nil
+	}
+
+	return bson.D{{Key: "$or", Value: branches}}, nil
+}
+
+func isDescending(field bson.E) bool {
+	direction, ok := field.Value.(int)
+
+	return ok && direction < 0
+}
+
+func reverseSort(sort bson.D) bson.D {
+	reversed := bson.D{}
+
+	for _, field := range sort {
+		direction := 1
+
+		if !isDescending(field) {
+			direction = -1
+		}
+
+		reversed = append(reversed, bson.E{Key: field.Key, Value: direction})
+	}
+
+	return reversed
+}
+
+func reverse[E any](items []E) {
+	for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+		items[i], items[j] = items[j], items[i]
+	}
+}
+
+func readOptions(opts []ReadOption) ReadOptions {
+	options := ReadOptions{}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+func findOneOptions(opts []ReadOption) *options.FindOneOptions {
+	return options.FindOne().SetProjection(readOptions(opts).Projection)
+}
+
+// scopeFilter restricts filter to documents that are not soft deleted, unless
+// the caller asked for them through IncludeDeleted.
+func scopeFilter(filter interface{}, opts []ReadOption) interface{} {
+	if readOptions(opts).IncludeDeleted {
+		return filter
+	}
+
+	notDeleted := bson.D{{Key: "deletedAt", Value: nil}}
+
+	if filter == nil {
+		return notDeleted
+	}
+
+	return bson.D{{Key: "$and", Value: bson.A{filter, notDeleted}}}
 }
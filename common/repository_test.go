@@ -0,0 +1,85 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func Test_KeysetFilter(t *testing.T) {
+	sort := bson.D{{Key: "price", Value: -1}, {Key: "_id", Value: 1}}
+
+	tests := []struct {
+		name     string
+		keyset   Keyset
+		expected bson.D
+	}{
+		{
+			name:   "forward",
+			keyset: Keyset{Values: []interface{}{"10", int64(7)}},
+			expected: bson.D{{Key: "$or", Value: bson.A{
+				bson.D{{Key: "$or", Value: bson.A{
+					bson.D{{Key: "price", Value: bson.D{{Key: "$lt", Value: "10"}}}},
+					bson.D{{Key: "price", Value: nil}},
+				}}},
+				bson.D{{Key: "price", Value: "10"}, {Key: "_id", Value: bson.D{{Key: "$gt", Value: int64(7)}}}},
+			}}},
+		},
+		{
+			name:   "backward",
+			keyset: Keyset{Values: []interface{}{"10", int64(7)}, Backward: true},
+			expected: bson.D{{Key: "$or", Value: bson.A{
+				bson.D{{Key: "price", Value: bson.D{{Key: "$gt", Value: "10"}}}},
+				bson.D{{Key: "price", Value: "10"}, {Key: "$or", Value: bson.A{
+					bson.D{{Key: "_id", Value: bson.D{{Key: "$lt", Value: int64(7)}}}},
+					bson.D{{Key: "_id", Value: nil}},
+				}}},
+			}}},
+		},
+		{
+			name:   "missing value",
+			keyset: Keyset{Values: []interface{}{nil, int64(7)}},
+			expected: bson.D{{Key: "$or", Value: bson.A{
+				bson.D{{Key: "price", Value: nil}, {Key: "_id", Value: bson.D{{Key: "$gt", Value: int64(7)}}}},
+			}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := keysetFilter(sort, tt.keyset)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_KeysetFilter_Values_Do_Not_Match_Sort(t *testing.T) {
+	_, err := keysetFilter(bson.D{{Key: "_id", Value: 1}}, Keyset{Values: []interface{}{"10", int64(7)}})
+
+	assert.Error(t, err)
+}
+
+func Test_UpsertModel_Skips_Deleted_Documents(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	deletedBy := "admin"
+	id := primitive.NewObjectID()
+	document := Document{Id: id, Version: 3, CreatedBy: "seed", DeletedAt: &now, DeletedBy: &deletedBy}
+
+	// Act
+	model, err := upsertModel(document, "import", now)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, bson.D{{Key: "_id", Value: id}, {Key: "deletedAt", Value: nil}}, model.Filter)
+
+	set := model.Update.(bson.D)[0].Value.(bson.M)
+
+	assert.NotContains(t, set, "deletedAt")
+	assert.NotContains(t, set, "deletedBy")
+	assert.Equal(t, "import", set["modifiedBy"])
+}
@@ -1,8 +1,21 @@
 package responses
 
 type PaginatedResponse struct {
-	Start    int64       `json:"start"`
-	PageSize int64       `json:"pageSize"`
-	Total    int64       `json:"total"`
-	Data     interface{} `json:"data"`
+	Start      int64       `json:"start"`
+	PageSize   int64       `json:"pageSize"`
+	Total      int64       `json:"total"`
+	Data       interface{} `json:"data"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+	PrevCursor string      `json:"prevCursor,omitempty"`
+	Links      PageLinks   `json:"links"`
+}
+
+// PageLinks holds the URLs of the page and of the pages around it. A link is
+// empty when there is no such page.
+type PageLinks struct {
+	Self  string `json:"self"`
+	First string `json:"first"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last"`
 }
@@ -0,0 +1,55 @@
+package common
+
+import (
+	"context"
+
+	"github.com/juanmaabanto/go-ms-beers/common/database"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// UnitOfWork runs fn so that the repository writes it makes through the
+// context it receives are applied all together or not at all. A unit of work
+// started inside another one joins it.
+type UnitOfWork interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// MongoUnitOfWork runs fn in a MongoDB transaction. The repositories join it
+// through the session the context carries. fn may run again when the
+// transaction hits a transient error, so it must not have other side effects.
+// Transactions need a replica set or a sharded cluster.
+type MongoUnitOfWork struct {
+	client *mongo.Client
+}
+
+func NewMongoUnitOfWork(connection database.MongoConnection) MongoUnitOfWork {
+	return MongoUnitOfWork{client: &connection.Client}
+}
+
+func (u MongoUnitOfWork) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	session, err := u.client.StartSession()
+
+	if err != nil {
+		return err
+	}
+
+	defer session.EndSession(context.Background())
+
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessionContext)
+	})
+
+	return err
+}
+
+// NoopUnitOfWork runs fn as it is, each write applied on its own. It serves
+// standalone MongoDB servers, which have no transactions, and tests.
+type NoopUnitOfWork struct{}
+
+func (NoopUnitOfWork) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -46,21 +46,75 @@ var doc = `{
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "word to search",
+                        "description": "text to search in the names, taken literally",
                         "name": "name",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "how the name is matched: contains (default), prefix, or text to rank by relevance",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated breweries",
+                        "name": "brewery",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated countries",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated ISO 4217 currency codes",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum price",
+                        "name": "minPrice",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum price",
+                        "name": "maxPrice",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields among id, name, brewery, country, price, currency and abv, a leading - sorts descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Number of results per page",
+                        "description": "Number of results per page, 50 by default and capped by MAX_PAGE_SIZE",
                         "name": "pageSize",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Page number",
+                        "type": "integer",
+                        "description": "Number of beers to skip",
                         "name": "start",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor or prevCursor of a previous page, replaces start",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of the beers to return, all of them by default",
+                        "name": "fields",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -68,6 +122,12 @@ var doc = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/responses.PaginatedResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "RFC 8288 links to the self, first, prev, next and last pages"
+                            }
                         }
                     },
                     "400": {
@@ -76,6 +136,12 @@ var doc = `{
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -140,7 +206,106 @@ var doc = `{
                 }
             }
         },
-        "/beers/{beerId}": {
+        "/beers/export": {
+            "get": {
+                "description": "The beers are streamed as they are read. The format is taken from the format parameter or the Accept header, JSON by default.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Beers"
+                ],
+                "summary": "Export every beer matching the filters.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv, ndjson or json",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "text to search in the names, taken literally",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "how the name is matched: contains (default), prefix, or text to rank by relevance",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated breweries",
+                        "name": "brewery",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated countries",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated ISO 4217 currency codes",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum price",
+                        "name": "minPrice",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum price",
+                        "name": "maxPrice",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields among id, name, brewery, country, price, currency and abv, a leading - sorts descending",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.BeerResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/beers/facets": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -151,21 +316,124 @@ var doc = `{
                 "tags": [
                     "Beers"
                 ],
-                "summary": "Get a beer by Id.",
+                "summary": "Count the beers of a search by country, brewery, currency and price.",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Beer Id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "text to search in the names, taken literally",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "how the name is matched: contains (default), prefix, or text",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated breweries",
+                        "name": "brewery",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated countries",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated ISO 4217 currency codes",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum price",
+                        "name": "minPrice",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum price",
+                        "name": "maxPrice",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "width of the price buckets, 10 by default and at least 0.01",
+                        "name": "priceInterval",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.BeerResponse"
+                            "$ref": "#/definitions/response.FacetsResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/beers/import": {
+            "post": {
+                "description": "The file is the request body and is read as it arrives. CSV files name their columns in the first row.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Beers"
+                ],
+                "summary": "Import beers from a CSV or NDJSON file.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv or ndjson, taken from the Content-Type by default",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated column=field pairs renaming the CSV columns, as in Precio=price",
+                        "name": "map",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "insert (default) only creates beers, upsert also replaces the existing ones",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "validate and report without writing",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ImportResponse"
                         }
                     },
                     "400": {
@@ -174,8 +442,8 @@ var doc = `{
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -189,7 +457,7 @@ var doc = `{
                 }
             }
         },
-        "/beers/{beerId}/boxprice": {
+        "/beers/{beerId}": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -200,35 +468,38 @@ var doc = `{
                 "tags": [
                     "Beers"
                 ],
-                "summary": "Return total price.",
+                "summary": "Get a beer by Id.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "search one beer for Id",
+                        "description": "Beer Id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "money to pay",
-                        "name": "currency",
+                        "type": "string",
+                        "description": "comma separated fields of the beer to return, all of them by default",
+                        "name": "fields",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "quantity",
-                        "name": "quantity",
-                        "in": "query"
+                        "type": "string",
+                        "description": "ETag of the cached beer",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responses.PaginatedResponse"
+                            "$ref": "#/definitions/response.BeerResponse"
                         }
                     },
+                    "304": {
+                        "description": ""
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -248,48 +519,676 @@ var doc = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "command.CreateBeer": {
-            "type": "object",
-            "required": [
-                "brewery",
-                "country",
-                "currency",
-                "id",
-                "name",
-                "price"
-            ],
-            "properties": {
-                "brewery": {
-                    "type": "string",
-                    "maxLength": 30
-                },
-                "country": {
-                    "type": "string",
-                    "maxLength": 20
-                },
-                "currency": {
-                    "type": "string",
-                    "maxLength": 5
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 30
-                },
-                "price": {
-                    "type": "number"
-                }
-            }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Beers"
+                ],
+                "summary": "Replace an existing beer.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Beer Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the beer must still have",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Object to be replaced.",
+                        "name": "command",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/command.UpdateBeer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Beers"
+                ],
+                "summary": "Delete a beer, it can be restored unless it is purged.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Beer Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Remove the beer permanently, requires the X-Api-Key header",
+                        "name": "purge",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the beer must still have",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "consumes": [
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Beers"
+                ],
+                "summary": "Partially update a beer with a JSON Merge Patch document.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Beer Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the beer must still have",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Fields to be changed, null removes a field.",
+                        "name": "command",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/command.UpdateBeer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/beers/{beerId}/boxprice": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Beers"
+                ],
+                "summary": "Return total price.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "search one beer for Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency code to pay in",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "box size, 6 by default; sizes and discounts come from the pricing rules",
+                        "name": "quantity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 3166 country the box is shipped to, adds its taxes",
+                        "name": "destination",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PriceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/beers/{beerId}/restore": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Beers"
+                ],
+                "summary": "Restore a deleted beer.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Beer Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/beers:batch": {
+            "post": {
+                "description": "Every beer is validated and created on its own and reported in items. An ordered batch stops at the first beer that fails.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Beers"
+                ],
+                "summary": "Create several beers at once.",
+                "parameters": [
+                    {
+                        "description": "Beers to be created.",
+                        "name": "command",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/command.CreateBeers"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotes": {
+            "post": {
+                "description": "Lines whose beer is missing or whose quantity is not a box size report an error and are left out of the total.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quotes"
+                ],
+                "summary": "Price a cart of beer boxes.",
+                "parameters": [
+                    {
+                        "description": "Lines to price and currency to pay in.",
+                        "name": "query",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/query.GetQuote"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.QuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "command.CreateBeer": {
+            "type": "object",
+            "required": [
+                "brewery",
+                "country",
+                "currency",
+                "id",
+                "name",
+                "price"
+            ],
+            "properties": {
+                "abv": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "brewery": {
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "country": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "currency": {
+                    "type": "string",
+                    "maxLength": 5
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "command.CreateBeers": {
+            "type": "object",
+            "properties": {
+                "beers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/command.CreateBeer"
+                    }
+                },
+                "ordered": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "command.UpdateBeer": {
+            "type": "object",
+            "required": [
+                "brewery",
+                "country",
+                "currency",
+                "name",
+                "price"
+            ],
+            "properties": {
+                "abv": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "brewery": {
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "country": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "currency": {
+                    "type": "string",
+                    "maxLength": 5
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "query.GetQuote": {
+            "type": "object",
+            "required": [
+                "currency",
+                "lines"
+            ],
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "destination": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/query.QuoteLine"
+                    }
+                }
+            }
+        },
+        "query.QuoteLine": {
+            "type": "object",
+            "required": [
+                "beerId",
+                "quantity"
+            ],
+            "properties": {
+                "beerId": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.AdjustmentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.BatchItemResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.BatchResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.BatchItemResponse"
+                    }
+                }
+            }
         },
         "response.BeerResponse": {
             "type": "object",
             "properties": {
+                "abv": {
+                    "type": "number"
+                },
                 "brewery": {
                     "type": "string"
                 },
@@ -307,6 +1206,237 @@ var doc = `{
                 },
                 "price": {
                     "type": "number"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.FacetBucketResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.FacetsResponse": {
+            "type": "object",
+            "properties": {
+                "breweries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.FacetBucketResponse"
+                    }
+                },
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.FacetBucketResponse"
+                    }
+                },
+                "currencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.FacetBucketResponse"
+                    }
+                },
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PriceBucketResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ImportRowResponse"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "integer"
+                },
+                "truncated": {
+                    "description": "Truncated tells that more rows failed than the ones listed in Errors.",
+                    "type": "boolean"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.ImportRowResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.MoneyResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.PriceBucketResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "number"
+                },
+                "to": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.PriceResponse": {
+            "type": "object",
+            "properties": {
+                "convertedUnitPrice": {
+                    "$ref": "#/definitions/response.MoneyResponse"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "destination": {
+                    "type": "string"
+                },
+                "discounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AdjustmentResponse"
+                    }
+                },
+                "exchangeRate": {
+                    "$ref": "#/definitions/response.RateResponse"
+                },
+                "priceTotal": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "type": "number"
+                },
+                "taxes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AdjustmentResponse"
+                    }
+                },
+                "total": {
+                    "type": "number"
+                },
+                "unitPrice": {
+                    "$ref": "#/definitions/response.MoneyResponse"
+                }
+            }
+        },
+        "response.QuoteLineResponse": {
+            "type": "object",
+            "properties": {
+                "beerId": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "price": {
+                    "$ref": "#/definitions/response.PriceResponse"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.QuoteResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "destination": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.QuoteLineResponse"
+                    }
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.RateResponse": {
+            "type": "object",
+            "properties": {
+                "provider": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
                 }
             }
         },
@@ -333,13 +1463,42 @@ var doc = `{
                 }
             }
         },
+        "responses.PageLinks": {
+            "type": "object",
+            "properties": {
+                "first": {
+                    "type": "string"
+                },
+                "last": {
+                    "type": "string"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "prev": {
+                    "type": "string"
+                },
+                "self": {
+                    "type": "string"
+                }
+            }
+        },
         "responses.PaginatedResponse": {
             "type": "object",
             "properties": {
                 "data": {},
+                "links": {
+                    "$ref": "#/definitions/responses.PageLinks"
+                },
+                "nextCursor": {
+                    "type": "string"
+                },
                 "pageSize": {
                     "type": "integer"
                 },
+                "prevCursor": {
+                    "type": "string"
+                },
                 "start": {
                     "type": "integer"
                 },
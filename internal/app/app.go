@@ -11,11 +11,20 @@ type Application struct {
 }
 
 type Commands struct {
-	CreateBeer command.CreateBeerHandler
+	CreateBeer  command.CreateBeerHandler
+	CreateBeers command.CreateBeersHandler
+	ImportBeers command.ImportBeersHandler
+	UpdateBeer  command.UpdateBeerHandler
+	PatchBeer   command.PatchBeerHandler
+	DeleteBeer  command.DeleteBeerHandler
+	RestoreBeer command.RestoreBeerHandler
 }
 
 type Queries struct {
-	GetBeerById query.GetBeerByIdHandler
-	ListBeers   query.ListBeersHandler
-	GetBoxPrice query.GetBoxPriceHandler
+	GetBeerById   query.GetBeerByIdHandler
+	ListBeers     query.ListBeersHandler
+	GetBeerFacets query.GetBeerFacetsHandler
+	ExportBeers   query.ExportBeersHandler
+	GetBoxPrice   query.GetBoxPriceHandler
+	GetQuote      query.GetQuoteHandler
 }
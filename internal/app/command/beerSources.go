@@ -0,0 +1,254 @@
+package command
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	errorsN "errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+)
+
+// Formats of the files read by NewBeerSource.
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// decimalRangeMessage explains why a number is rejected with
+// decimal.ErrOutOfRange.
+const decimalRangeMessage = "The number must have at most 34 significant digits and an exponent between -6176 and 6111."
+
+// maxNDJSONLine bounds the length of a line of an NDJSON file.
+const maxNDJSONLine = 1024 * 1024
+
+// FormulaPrefixes are the first characters that make a spreadsheet run a cell
+// as a formula. CSV exports quote such text with a leading ' and the quote is
+// removed when the text is read back.
+const FormulaPrefixes = "=+-@\t\r"
+
+// beerColumns are the fields of CreateBeer a CSV column is read into. Only abv
+// may be left out.
+var beerColumns = []string{"id", "name", "brewery", "country", "price", "currency", "abv"}
+
+// NewBeerSource reads beers from r in format. The mapping renames CSV columns
+// to the fields of a beer and is ignored for NDJSON.
+func NewBeerSource(format string, r io.Reader, mapping map[string]string) (BeerSource, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVBeerSource(r, mapping)
+	case FormatNDJSON:
+		return newNDJSONBeerSource(r), nil
+	default:
+		return nil, errors.NewBadRequestError(fmt.Sprintf("The format must be %s or %s.", FormatCSV, FormatNDJSON))
+	}
+}
+
+// ParseColumnMapping reads a mapping such as "Precio=price,Código=id" from CSV
+// column names to beer fields.
+func ParseColumnMapping(text string) (map[string]string, error) {
+	mapping := map[string]string{}
+
+	for _, pair := range strings.Split(text, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, "=", 2)
+
+		if len(parts) != 2 || !isBeerColumn(strings.TrimSpace(parts[1])) {
+			return nil, errors.NewBadRequestError(fmt.Sprintf("The mapping %q must name a column and one of the fields %s.", pair, strings.Join(beerColumns, ", ")))
+		}
+
+		mapping[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return mapping, nil
+}
+
+type csvBeerSource struct {
+	reader  *csv.Reader
+	columns map[string]int
+	width   int
+}
+
+// newCSVBeerSource reads the header of a CSV file. Columns are matched to the
+// fields by the mapping, or by their own name ignoring case when the mapping
+// does not name them.
+func newCSVBeerSource(r io.Reader, mapping map[string]string) (BeerSource, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+
+	if err != nil {
+		return nil, errors.NewBadRequestError("The CSV file has no header.")
+	}
+
+	columns := map[string]int{}
+
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+
+		if field, ok := mapping[name]; ok {
+			name = field
+		}
+
+		if field := strings.ToLower(name); isBeerColumn(field) {
+			columns[field] = i
+		}
+	}
+
+	for _, field := range beerColumns {
+		if _, ok := columns[field]; !ok && field != "abv" {
+			return nil, errors.NewBadRequestError(fmt.Sprintf("The CSV file has no column for %s.", field))
+		}
+	}
+
+	return &csvBeerSource{reader: reader, columns: columns, width: len(header)}, nil
+}
+
+func (s *csvBeerSource) Next() (int, CreateBeer, error) {
+	record, err := s.reader.Read()
+
+	if err == io.EOF {
+		return 0, CreateBeer{}, io.EOF
+	}
+
+	if parseErr, ok := err.(*csv.ParseError); ok {
+		return parseErr.StartLine, CreateBeer{}, errors.NewValidationError(map[string]string{
+			"row": fmt.Sprintf("The row is malformed: %s.", parseErr.Err),
+		})
+	}
+
+	if err != nil {
+		return 0, CreateBeer{}, errors.NewBadRequestError(fmt.Sprintf("The CSV file could not be read: %s.", err))
+	}
+
+	line, _ := s.reader.FieldPos(0)
+
+	if len(record) != s.width {
+		return line, CreateBeer{}, errors.NewValidationError(map[string]string{
+			"row": fmt.Sprintf("The row has %d fields and the header %d.", len(record), s.width),
+		})
+	}
+
+	value := func(field string) string {
+		if i, ok := s.columns[field]; ok {
+			return strings.TrimSpace(record[i])
+		}
+
+		return ""
+	}
+
+	errs := map[string]string{}
+	text := func(field string) string {
+		value := value(field)
+
+		if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(FormulaPrefixes, rune(value[1])) {
+			return value[1:]
+		}
+
+		return value
+	}
+
+	item := CreateBeer{
+		Name:     text("name"),
+		Brewery:  text("brewery"),
+		Country:  text("country"),
+		Currency: text("currency"),
+	}
+
+	if id, err := strconv.ParseInt(value("id"), 10, 64); err == nil {
+		item.Id = id
+	} else {
+		errs["id"] = "The id must be an integer."
+	}
+
+	if price, err := decimal.Parse(value("price")); err == nil {
+		item.Price = price
+	} else {
+		errs["price"] = numberError(err, "The price must be a number.")
+	}
+
+	if text := value("abv"); text != "" {
+		if abv, err := decimal.Parse(text); err == nil {
+			item.Abv = abv
+		} else {
+			errs["abv"] = numberError(err, "The abv must be a number.")
+		}
+	}
+
+	if len(errs) > 0 {
+		return line, item, errors.NewValidationError(errs)
+	}
+
+	return line, item, nil
+}
+
+type ndjsonBeerSource struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// newNDJSONBeerSource reads a beer from every line of r, as in the body of
+// POST /beers. Blank lines are skipped.
+func newNDJSONBeerSource(r io.Reader) BeerSource {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxNDJSONLine)
+
+	return &ndjsonBeerSource{scanner: scanner}
+}
+
+func (s *ndjsonBeerSource) Next() (int, CreateBeer, error) {
+	for s.scanner.Scan() {
+		s.line++
+
+		text := strings.TrimSpace(s.scanner.Text())
+
+		if text == "" {
+			continue
+		}
+
+		item := CreateBeer{}
+
+		if err := json.Unmarshal([]byte(text), &item); err != nil {
+			return s.line, CreateBeer{}, errors.NewValidationError(map[string]string{"row": numberError(err, "The line is not a valid beer.")})
+		}
+
+		return s.line, item, nil
+	}
+
+	if err := s.scanner.Err(); err != nil {
+		return s.line + 1, CreateBeer{}, errors.NewBadRequestError(fmt.Sprintf("The NDJSON file is malformed: %s.", err))
+	}
+
+	return 0, CreateBeer{}, io.EOF
+}
+
+// numberError is the message of a number that could not be read, message
+// unless it is too large for a decimal.
+func numberError(err error, message string) string {
+	if errorsN.Is(err, decimal.ErrOutOfRange) {
+		return decimalRangeMessage
+	}
+
+	return message
+}
+
+func isBeerColumn(field string) bool {
+	for _, column := range beerColumns {
+		if column == field {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,94 @@
+package command
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewBeerSource_Unknown_Format(t *testing.T) {
+	_, err := NewBeerSource("xml", strings.NewReader(""), nil)
+
+	assert.IsType(t, errors.ApplicationError{}, err)
+}
+
+func Test_ParseColumnMapping(t *testing.T) {
+	mapping, err := ParseColumnMapping("Precio=price, Código = id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"Precio": "price", "Código": "id"}, mapping)
+
+	_, err = ParseColumnMapping("Precio=cost")
+
+	assert.IsType(t, errors.ApplicationError{}, err)
+}
+
+func Test_CSVBeerSource_Reads_Mapped_Columns(t *testing.T) {
+	// Arrange
+	file := "\ufeffCódigo,Name,Brewery,Country,Precio,Currency\n" +
+		"1,Pilsen,Backus,PE,3.50,PEN\n" +
+		"2,Cusqueña,Backus,PE,abc,PEN\n" +
+		"3,Corona\n"
+	mapping, _ := ParseColumnMapping("Código=id,Precio=price")
+
+	// Act
+	source, err := NewBeerSource(FormatCSV, strings.NewReader(file), mapping)
+	line, first, firstErr := source.Next()
+	_, _, secondErr := source.Next()
+	_, _, thirdErr := source.Next()
+	_, _, end := source.Next()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, firstErr)
+	assert.Equal(t, 2, line)
+	assert.Equal(t, CreateBeer{Id: 1, Name: "Pilsen", Brewery: "Backus", Country: "PE", Price: decimal.MustParse("3.50"), Currency: "PEN"}, first)
+	assert.Contains(t, secondErr.(errors.ApplicationError).Errors(), "price")
+	assert.Contains(t, thirdErr.(errors.ApplicationError).Errors(), "row")
+	assert.Equal(t, io.EOF, end)
+}
+
+func Test_CSVBeerSource_Price_Out_Of_Range(t *testing.T) {
+	// Arrange
+	file := "id,name,brewery,country,price,currency\n" +
+		"1,Pilsen,Backus,PE,1.00000000000000000000000000000000001,PEN\n"
+
+	// Act
+	source, _ := NewBeerSource(FormatCSV, strings.NewReader(file), nil)
+	_, _, err := source.Next()
+
+	// Assert
+	assert.Equal(t, decimalRangeMessage, err.(errors.ApplicationError).Errors()["price"])
+}
+
+func Test_CSVBeerSource_Missing_Column(t *testing.T) {
+	_, err := NewBeerSource(FormatCSV, strings.NewReader("id,name,brewery,country,currency\n"), nil)
+
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Contains(t, err.Error(), "price")
+}
+
+func Test_NDJSONBeerSource(t *testing.T) {
+	// Arrange
+	file := `{"id":1,"name":"Pilsen","brewery":"Backus","country":"PE","price":"3.50","currency":"PEN"}` + "\n\n" +
+		"not json\n"
+
+	// Act
+	source, _ := NewBeerSource(FormatNDJSON, strings.NewReader(file), nil)
+	line, first, firstErr := source.Next()
+	secondLine, _, secondErr := source.Next()
+	_, _, end := source.Next()
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.Equal(t, 1, line)
+	assert.Equal(t, int64(1), first.Id)
+	assert.True(t, decimal.MustParse("3.50").Equal(first.Price))
+	assert.Equal(t, 3, secondLine)
+	assert.Equal(t, errors.ErrorTypeValidation, secondErr.(errors.ApplicationError).ErrorType())
+	assert.Equal(t, io.EOF, end)
+}
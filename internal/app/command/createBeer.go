@@ -2,59 +2,71 @@ package command
 
 import (
 	"context"
+	errorsN "errors"
 	"time"
 
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
 	"github.com/juanmaabanto/go-ms-beers/common/errors"
 	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
 type CreateBeer struct {
-	Id       int64   `json:"id" validate:"required"`
-	Name     string  `json:"name" validate:"required,max=30"`
-	Brewery  string  `json:"brewery" validate:"required,max=30"`
-	Country  string  `json:"country" validate:"required,max=20"`
-	Price    float64 `json:"price" validate:"required"`
-	Currency string  `json:"currency" validate:"required,max=5"`
+	Id       int64           `json:"id" validate:"required"`
+	Name     string          `json:"name" validate:"required,max=30"`
+	Brewery  string          `json:"brewery" validate:"required,max=30"`
+	Country  string          `json:"country" validate:"required,max=20"`
+	Price    decimal.Decimal `json:"price" validate:"required" swaggertype:"number"`
+	Currency string          `json:"currency" validate:"required,max=5"`
+	Abv      decimal.Decimal `json:"abv" validate:"gte=0,lte=100" swaggertype:"number"`
 }
 
 type CreateBeerHandler struct {
 	repo beer.Repository
+	uow  common.UnitOfWork
 }
 
-func NewCreateBeerHandler(repo beer.Repository) CreateBeerHandler {
+func NewCreateBeerHandler(repo beer.Repository, uow common.UnitOfWork) CreateBeerHandler {
 	if repo == nil {
 		panic("nil repo beer")
 	}
 
-	return CreateBeerHandler{repo: repo}
+	if uow == nil {
+		panic("nil unit of work")
+	}
+
+	return CreateBeerHandler{repo: repo, uow: uow}
 }
 
+// Handle checks the id and inserts the beer in a single unit of work. A beer
+// created with the same id in the meantime still makes the insert fail with a
+// conflict.
 func (h CreateBeerHandler) Handle(ctx context.Context, command CreateBeer) (int64, error) {
-	count, err := h.repo.Count(ctx, bson.M{"_id": command.Id})
+	var id int64
 
-	if err != nil {
-		return 0, err
-	}
+	err := h.uow.WithTransaction(ctx, func(ctx context.Context) error {
+		count, err := h.repo.Count(ctx, bson.M{"_id": command.Id}, common.IncludeDeleted())
 
-	if count > 0 {
-		return 0, errors.NewConflictError("An element with the same id already exists.")
-	}
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return errors.NewConflictError("An element with the same id already exists.")
+		}
+
+		id, err = h.repo.InsertOne(ctx, newBeer(command, time.Now()))
 
-	item := beer.Beer{}
-	item.Id = command.Id
-	item.Name = command.Name
-	item.Brewery = command.Brewery
-	item.Country = command.Country
-	item.Price = command.Price
-	item.Currency = command.Currency
-	item.CreatedAt = time.Now()
-	item.CreatedBy = "admin"
+		return err
+	})
 
-	id, err := h.repo.InsertOne(ctx, item)
+	if errorsN.Is(err, common.ErrDuplicateKey) {
+		return 0, errors.NewConflictError("An element with the same id already exists.")
+	}
 
 	if err != nil {
-		return id, err
+		return 0, err
 	}
 
 	return id, nil
@@ -5,12 +5,32 @@ import (
 	errorsN "errors"
 	"testing"
 
+	"github.com/juanmaabanto/go-ms-beers/common"
 	"github.com/juanmaabanto/go-ms-beers/common/errors"
 	"github.com/juanmaabanto/go-ms-beers/common/mocks"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+type transactionKey struct{}
+
+// testUnitOfWork marks the context of the work so the repository calls made
+// inside it can be told apart.
+type testUnitOfWork struct {
+	calls int
+}
+
+func (u *testUnitOfWork) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+
+	return fn(context.WithValue(ctx, transactionKey{}, true))
+}
+
+func inTransaction(ctx context.Context) bool {
+	return ctx.Value(transactionKey{}) != nil
+}
+
 func Test_NewCreateBeerHandler(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
@@ -18,19 +38,29 @@ func Test_NewCreateBeerHandler(t *testing.T) {
 		}
 	}()
 
-	NewCreateBeerHandler(nil)
+	NewCreateBeerHandler(nil, common.NoopUnitOfWork{})
+}
+
+func Test_NewCreateBeerHandler_Nil_Unit_Of_Work(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("panic")
+		}
+	}()
+
+	NewCreateBeerHandler(new(mocks.MockRepository[beer.Beer, int64]), nil)
 }
 
 func Test_Handle_CreateBeer_Count_Error(t *testing.T) {
 	// Arrange
-	mockRepo := new(mocks.MockRepository)
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
 	ctx := context.Background()
 	item := CreateBeer{Name: "test"}
 
-	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.M")).Return(int64(0), errorsN.New("An error has occurred"))
+	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.M"), mock.AnythingOfType("[]common.ReadOption")).Return(int64(0), errorsN.New("An error has occurred"))
 
 	// Act
-	testCommand := NewCreateBeerHandler(mockRepo)
+	testCommand := NewCreateBeerHandler(mockRepo, common.NoopUnitOfWork{})
 	_, err := testCommand.Handle(ctx, item)
 
 	// Assert
@@ -42,14 +72,14 @@ func Test_Handle_CreateBeer_Count_Error(t *testing.T) {
 
 func Test_Handle_CreateBeer_Count_Is_Greater_Than_Zero(t *testing.T) {
 	// Arrange
-	mockRepo := new(mocks.MockRepository)
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
 	ctx := context.Background()
 	item := CreateBeer{Name: "test"}
 
-	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.M")).Return(int64(1), nil)
+	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.M"), mock.AnythingOfType("[]common.ReadOption")).Return(int64(1), nil)
 
 	// Act
-	testCommand := NewCreateBeerHandler(mockRepo)
+	testCommand := NewCreateBeerHandler(mockRepo, common.NoopUnitOfWork{})
 	_, err := testCommand.Handle(ctx, item)
 
 	// Assert
@@ -62,16 +92,16 @@ func Test_Handle_CreateBeer_Count_Is_Greater_Than_Zero(t *testing.T) {
 
 func Test_Handle_CreateBeer_Insert_Completed(t *testing.T) {
 	// Arrange
-	mockRepo := new(mocks.MockRepository)
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
 	ctx := context.Background()
 	item := CreateBeer{Name: "test"}
 	expected := int64(1)
 
-	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.M")).Return(int64(0), nil)
+	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.M"), mock.AnythingOfType("[]common.ReadOption")).Return(int64(0), nil)
 	mockRepo.On("InsertOne", ctx, mock.AnythingOfType("beer.Beer")).Return(expected, nil)
 
 	// Act
-	testCommand := NewCreateBeerHandler(mockRepo)
+	testCommand := NewCreateBeerHandler(mockRepo, common.NoopUnitOfWork{})
 	result, err := testCommand.Handle(ctx, item)
 
 	// Assert
@@ -84,15 +114,15 @@ func Test_Handle_CreateBeer_Insert_Completed(t *testing.T) {
 
 func Test_Handle_CreateBeer_Insert_Error(t *testing.T) {
 	// Arrange
-	mockRepo := new(mocks.MockRepository)
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
 	ctx := context.Background()
 	item := CreateBeer{Name: "test"}
 
-	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.M")).Return(int64(0), nil)
+	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.M"), mock.AnythingOfType("[]common.ReadOption")).Return(int64(0), nil)
 	mockRepo.On("InsertOne", ctx, mock.AnythingOfType("beer.Beer")).Return(int64(0), errorsN.New("An error has occurred"))
 
 	// Act
-	testCommand := NewCreateBeerHandler(mockRepo)
+	testCommand := NewCreateBeerHandler(mockRepo, common.NoopUnitOfWork{})
 	_, err := testCommand.Handle(ctx, item)
 
 	// Assert
@@ -102,3 +132,43 @@ func Test_Handle_CreateBeer_Insert_Error(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), "An error has occurred")
 }
+
+func Test_Handle_CreateBeer_In_Unit_Of_Work(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	uow := &testUnitOfWork{}
+	item := CreateBeer{Id: 1, Name: "test"}
+
+	mockRepo.On("Count", mock.MatchedBy(inTransaction), mock.AnythingOfType("primitive.M"), mock.AnythingOfType("[]common.ReadOption")).Return(int64(0), nil)
+	mockRepo.On("InsertOne", mock.MatchedBy(inTransaction), mock.AnythingOfType("beer.Beer")).Return(int64(1), nil)
+
+	// Act
+	testCommand := NewCreateBeerHandler(mockRepo, uow)
+	_, err := testCommand.Handle(context.Background(), item)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, uow.calls)
+}
+
+func Test_Handle_CreateBeer_Duplicate_Key(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	item := CreateBeer{Id: 1, Name: "test"}
+
+	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.M"), mock.AnythingOfType("[]common.ReadOption")).Return(int64(0), nil)
+	mockRepo.On("InsertOne", ctx, mock.AnythingOfType("beer.Beer")).Return(int64(0), common.ErrDuplicateKey)
+
+	// Act
+	testCommand := NewCreateBeerHandler(mockRepo, common.NoopUnitOfWork{})
+	_, err := testCommand.Handle(ctx, item)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeConflict, err.(errors.ApplicationError).ErrorType())
+}
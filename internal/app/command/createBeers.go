@@ -0,0 +1,151 @@
+package command
+
+import (
+	"context"
+	errorsN "errors"
+	"fmt"
+	"time"
+
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/labstack/echo/v4"
+)
+
+// maxBatchSize bounds the beers created by a single CreateBeers.
+const maxBatchSize = 500
+
+// Statuses of the beers of a CreateBeers.
+const (
+	StatusCreated  = "created"
+	StatusConflict = "conflict"
+	StatusInvalid  = "invalid"
+	// StatusSkipped marks the beers of an ordered batch after a failure.
+	StatusSkipped = "skipped"
+	StatusFailed  = "failed"
+)
+
+// CreateBeers creates several beers at once. An ordered batch stops at the
+// first beer that cannot be created, an unordered one tries all of them.
+type CreateBeers struct {
+	Ordered bool         `json:"ordered"`
+	Beers   []CreateBeer `json:"beers"`
+}
+
+// CreateBeersResult tells what happened to the beer at Index of the batch.
+// Err holds the reason of the statuses other than created.
+type CreateBeersResult struct {
+	Index  int
+	Id     int64
+	Status string
+	Err    error
+}
+
+type CreateBeersHandler struct {
+	repo      beer.Repository
+	validator echo.Validator
+}
+
+func NewCreateBeersHandler(repo beer.Repository, validator echo.Validator) CreateBeersHandler {
+	if repo == nil {
+		panic("nil repo beer")
+	}
+
+	if validator == nil {
+		panic("nil validator")
+	}
+
+	return CreateBeersHandler{repo: repo, validator: validator}
+}
+
+func (h CreateBeersHandler) Handle(ctx context.Context, command CreateBeers) ([]CreateBeersResult, error) {
+	if len(command.Beers) == 0 || len(command.Beers) > maxBatchSize {
+		return nil, errors.NewValidationError(map[string]string{
+			"beers": fmt.Sprintf("A batch must have between 1 and %d beers.", maxBatchSize),
+		})
+	}
+
+	results := make([]CreateBeersResult, len(command.Beers))
+	documents := []beer.Beer{}
+	positions := []int{}
+	seen := map[int64]bool{}
+	stopped := false
+	now := time.Now()
+
+	for i, item := range command.Beers {
+		results[i] = CreateBeersResult{Index: i, Id: item.Id}
+
+		if stopped {
+			results[i].Status = StatusSkipped
+			results[i].Err = common.ErrNotInserted
+			continue
+		}
+
+		invalid := h.validator.Validate(item)
+
+		switch {
+		case invalid != nil:
+			results[i].Status = StatusInvalid
+			results[i].Err = invalid
+		case seen[item.Id]:
+			results[i].Status = StatusConflict
+			results[i].Err = errors.NewConflictError("The id appears earlier in the batch.")
+		default:
+			seen[item.Id] = true
+			documents = append(documents, newBeer(item, now))
+			positions = append(positions, i)
+			continue
+		}
+
+		stopped = command.Ordered
+	}
+
+	if len(documents) == 0 {
+		return results, nil
+	}
+
+	inserted, err := h.repo.InsertMany(ctx, documents, command.Ordered)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for j, i := range positions {
+		var itemErr error
+
+		if j < len(inserted.Errors) {
+			itemErr = inserted.Errors[j]
+		}
+
+		switch {
+		case itemErr == nil:
+			results[i].Status = StatusCreated
+		case errorsN.Is(itemErr, common.ErrDuplicateKey):
+			results[i].Status = StatusConflict
+			results[i].Err = errors.NewConflictError("An element with the same id already exists.")
+		case errorsN.Is(itemErr, common.ErrNotInserted):
+			results[i].Status = StatusSkipped
+			results[i].Err = itemErr
+		default:
+			results[i].Status = StatusFailed
+			results[i].Err = itemErr
+		}
+	}
+
+	return results, nil
+}
+
+func newBeer(command CreateBeer, now time.Time) beer.Beer {
+	item := beer.Beer{}
+	item.Id = command.Id
+	item.Name = command.Name
+	item.Brewery = command.Brewery
+	item.Country = command.Country
+	item.Price = command.Price
+	item.Currency = command.Currency
+	item.Abv = command.Abv
+	item.CreatedAt = now
+	item.CreatedBy = "admin"
+
+	return item
+}
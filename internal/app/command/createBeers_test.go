@@ -0,0 +1,151 @@
+package command
+
+import (
+	"context"
+	errorsN "errors"
+	"testing"
+
+	"github.com/go-playground/validator"
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/common/mocks"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/juanmaabanto/go-ms-beers/internal/validations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func validBeer(id int64) CreateBeer {
+	return CreateBeer{Id: id, Name: "Pilsen", Brewery: "Backus", Country: "PE", Price: decimal.MustParse("3.50"), Currency: "PEN"}
+}
+
+func Test_NewCreateBeersHandler(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("panic")
+		}
+	}()
+
+	NewCreateBeersHandler(nil, validations.NewValidationUtil())
+}
+
+func Test_NewCreateBeersHandler_Nil_Validator(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("panic")
+		}
+	}()
+
+	NewCreateBeersHandler(new(mocks.MockRepository[beer.Beer, int64]), nil)
+}
+
+func Test_Handle_CreateBeers_Batch_Size(t *testing.T) {
+	for _, size := range []int{0, maxBatchSize + 1} {
+		mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+		beers := make([]CreateBeer, size)
+
+		testCommand := NewCreateBeersHandler(mockRepo, validations.NewValidationUtil())
+		_, err := testCommand.Handle(context.Background(), CreateBeers{Beers: beers})
+
+		mockRepo.AssertNotCalled(t, "InsertMany")
+
+		assert.IsType(t, errors.ApplicationError{}, err)
+		assert.Contains(t, err.(errors.ApplicationError).Errors(), "beers")
+	}
+}
+
+func Test_Handle_CreateBeers_Unordered(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	invalid := validBeer(2)
+	invalid.Name = ""
+	batch := CreateBeers{Beers: []CreateBeer{validBeer(1), invalid, validBeer(1), validBeer(3), validBeer(4)}}
+
+	mockRepo.On("InsertMany", ctx, mock.AnythingOfType("[]beer.Beer"), false).Return(common.InsertManyResult{
+		Errors: []error{nil, common.ErrDuplicateKey, nil},
+	}, nil)
+
+	// Act
+	testCommand := NewCreateBeersHandler(mockRepo, validations.NewValidationUtil())
+	results, err := testCommand.Handle(ctx, batch)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Len(t, mockRepo.Calls[0].Arguments.Get(1), 3)
+	assert.Equal(t, StatusCreated, results[0].Status)
+	assert.Equal(t, StatusInvalid, results[1].Status)
+	assert.IsType(t, validator.ValidationErrors{}, results[1].Err)
+	assert.Equal(t, StatusConflict, results[2].Status)
+	assert.Equal(t, StatusConflict, results[3].Status)
+	assert.Equal(t, int64(3), results[3].Id)
+	assert.Equal(t, StatusCreated, results[4].Status)
+}
+
+func Test_Handle_CreateBeers_Ordered_Stops_At_Invalid_Beer(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	invalid := validBeer(2)
+	invalid.Currency = ""
+	batch := CreateBeers{Ordered: true, Beers: []CreateBeer{validBeer(1), invalid, validBeer(3)}}
+
+	mockRepo.On("InsertMany", ctx, mock.AnythingOfType("[]beer.Beer"), true).Return(common.InsertManyResult{Errors: []error{nil}}, nil)
+
+	// Act
+	testCommand := NewCreateBeersHandler(mockRepo, validations.NewValidationUtil())
+	results, err := testCommand.Handle(ctx, batch)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Len(t, mockRepo.Calls[0].Arguments.Get(1), 1)
+	assert.Equal(t, StatusCreated, results[0].Status)
+	assert.Equal(t, StatusInvalid, results[1].Status)
+	assert.Equal(t, StatusSkipped, results[2].Status)
+}
+
+func Test_Handle_CreateBeers_Ordered_Stops_At_Conflict(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	batch := CreateBeers{Ordered: true, Beers: []CreateBeer{validBeer(1), validBeer(2), validBeer(3)}}
+
+	mockRepo.On("InsertMany", ctx, mock.AnythingOfType("[]beer.Beer"), true).Return(common.InsertManyResult{
+		Errors: []error{nil, common.ErrDuplicateKey, common.ErrNotInserted},
+	}, nil)
+
+	// Act
+	testCommand := NewCreateBeersHandler(mockRepo, validations.NewValidationUtil())
+	results, err := testCommand.Handle(ctx, batch)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Equal(t, StatusCreated, results[0].Status)
+	assert.Equal(t, StatusConflict, results[1].Status)
+	assert.Equal(t, StatusSkipped, results[2].Status)
+}
+
+func Test_Handle_CreateBeers_Insert_Error(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	mockRepo.On("InsertMany", ctx, mock.AnythingOfType("[]beer.Beer"), false).Return(common.InsertManyResult{}, errorsN.New("An error has occurred"))
+
+	// Act
+	testCommand := NewCreateBeersHandler(mockRepo, validations.NewValidationUtil())
+	_, err := testCommand.Handle(ctx, CreateBeers{Beers: []CreateBeer{validBeer(1)}})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Error(t, err)
+	assert.Equal(t, "An error has occurred", err.Error())
+}
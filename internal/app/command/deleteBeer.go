@@ -0,0 +1,81 @@
+package command
+
+import (
+	"context"
+	errorsN "errors"
+
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+)
+
+// DeleteBeer soft deletes a beer, or removes it for good when Purge is set.
+type DeleteBeer struct {
+	Id       int64
+	Versions []int64
+	Purge    bool
+}
+
+type DeleteBeerHandler struct {
+	repo beer.Repository
+}
+
+func NewDeleteBeerHandler(repo beer.Repository) DeleteBeerHandler {
+	if repo == nil {
+		panic("nil repo beer")
+	}
+
+	return DeleteBeerHandler{repo: repo}
+}
+
+func (h DeleteBeerHandler) Handle(ctx context.Context, command DeleteBeer) error {
+	var item beer.Beer
+	var err error
+
+	if command.Purge {
+		item, err = h.repo.FindById(ctx, command.Id, common.IncludeDeleted())
+	} else {
+		item, err = h.repo.FindById(ctx, command.Id)
+	}
+
+	if errorsN.Is(err, common.ErrNotFound) {
+		return errors.NewNotFoundError("beer")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := checkVersion(item.Version, command.Versions); err != nil {
+		return err
+	}
+
+	var count int64
+
+	if command.Purge {
+		version := common.AnyVersion
+
+		if command.Versions != nil {
+			version = item.Version
+		}
+
+		count, err = h.repo.DeleteById(ctx, command.Id, version)
+	} else {
+		count, err = h.repo.SoftDeleteById(ctx, command.Id, item.Version, "admin")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// A purge without a version only misses when the beer is already gone.
+	if count == 0 && command.Purge && command.Versions == nil {
+		return errors.NewNotFoundError("beer")
+	}
+
+	if count == 0 {
+		return errors.NewConflictError("The beer was modified by another request.")
+	}
+
+	return nil
+}
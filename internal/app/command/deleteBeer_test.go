@@ -0,0 +1,171 @@
+package command
+
+import (
+	"context"
+	errorsN "errors"
+	"testing"
+
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/common/mocks"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_NewDeleteBeerHandler(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("panic")
+		}
+	}()
+
+	NewDeleteBeerHandler(nil)
+}
+
+func Test_Handle_DeleteBeer_Soft_Deleted(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	stored.Version = 2
+	mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+	mockRepo.On("SoftDeleteById", ctx, int64(1), int64(2), "admin").Return(int64(1), nil)
+
+	// Act
+	testCommand := NewDeleteBeerHandler(mockRepo)
+	err := testCommand.Handle(ctx, DeleteBeer{Id: 1})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "DeleteById", ctx, int64(1), common.AnyVersion)
+
+	assert.NoError(t, err)
+}
+
+func Test_Handle_DeleteBeer_Not_Found(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	mockRepo.On("FindById", ctx, int64(1)).Return(nil, common.ErrNotFound)
+
+	// Act
+	testCommand := NewDeleteBeerHandler(mockRepo)
+	err := testCommand.Handle(ctx, DeleteBeer{Id: 1})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Error(t, err)
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeNotFound, err.(errors.ApplicationError).ErrorType())
+}
+
+func Test_Handle_DeleteBeer_Precondition_Failed(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	stored.Version = 2
+	mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+
+	// Act
+	testCommand := NewDeleteBeerHandler(mockRepo)
+	err := testCommand.Handle(ctx, DeleteBeer{Id: 1, Versions: []int64{1}})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Error(t, err)
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypePreconditionFailed, err.(errors.ApplicationError).ErrorType())
+}
+
+func Test_Handle_DeleteBeer_Purged(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	mockRepo.On("FindById", ctx, int64(1), mock.AnythingOfType("[]common.ReadOption")).Return(stored, nil)
+	mockRepo.On("DeleteById", ctx, int64(1), common.AnyVersion).Return(int64(1), nil)
+
+	// Act
+	testCommand := NewDeleteBeerHandler(mockRepo)
+	err := testCommand.Handle(ctx, DeleteBeer{Id: 1, Purge: true})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+}
+
+func Test_Handle_DeleteBeer_Purged_With_Version(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	stored.Version = 2
+	mockRepo.On("FindById", ctx, int64(1), mock.AnythingOfType("[]common.ReadOption")).Return(stored, nil)
+	mockRepo.On("DeleteById", ctx, int64(1), int64(2)).Return(int64(0), nil)
+
+	// Act
+	testCommand := NewDeleteBeerHandler(mockRepo)
+	err := testCommand.Handle(ctx, DeleteBeer{Id: 1, Versions: []int64{2}, Purge: true})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeConflict, err.(errors.ApplicationError).ErrorType())
+}
+
+func Test_Handle_DeleteBeer_Purged_Already_Gone(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	mockRepo.On("FindById", ctx, int64(1), mock.AnythingOfType("[]common.ReadOption")).Return(stored, nil)
+	mockRepo.On("DeleteById", ctx, int64(1), common.AnyVersion).Return(int64(0), nil)
+
+	// Act
+	testCommand := NewDeleteBeerHandler(mockRepo)
+	err := testCommand.Handle(ctx, DeleteBeer{Id: 1, Purge: true})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeNotFound, err.(errors.ApplicationError).ErrorType())
+}
+
+func Test_Handle_DeleteBeer_Error(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	mockRepo.On("FindById", ctx, int64(1), mock.AnythingOfType("[]common.ReadOption")).Return(stored, nil)
+	mockRepo.On("DeleteById", ctx, int64(1), common.AnyVersion).Return(int64(0), errorsN.New("An error has occurred"))
+
+	// Act
+	testCommand := NewDeleteBeerHandler(mockRepo)
+	err := testCommand.Handle(ctx, DeleteBeer{Id: 1, Purge: true})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Error(t, err)
+	assert.Equal(t, err.Error(), "An error has occurred")
+}
@@ -0,0 +1,290 @@
+package command
+
+import (
+	"context"
+	errorsN "errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/juanmaabanto/go-ms-beers/internal/validations"
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// importChunkSize is the number of rows written in a single round trip.
+const importChunkSize = 500
+
+// Import modes. Insert only creates new beers and reports the existing ones as
+// conflicts, upsert also replaces the existing ones. Soft deleted beers are
+// never replaced, both modes report them as conflicts.
+const (
+	ImportInsert = "insert"
+	ImportUpsert = "upsert"
+)
+
+// BeerSource yields the rows of an import one at a time, so files of any size
+// are read in constant memory. Next returns io.EOF after the last row. A row
+// that cannot be read returns a validation ApplicationError and the source
+// moves on to the next one, any other error ends the import.
+type BeerSource interface {
+	Next() (line int, item CreateBeer, err error)
+}
+
+// ImportBeers creates the beers of Source. With DryRun nothing is written, the
+// report tells what would happen. OnError receives every row that failed, in
+// order.
+type ImportBeers struct {
+	Source  BeerSource
+	Mode    string
+	DryRun  bool
+	OnError func(ImportRowError) error
+}
+
+// ImportReport counts the rows of an import.
+type ImportReport struct {
+	Rows    int  `json:"rows"`
+	Created int  `json:"created"`
+	Updated int  `json:"updated"`
+	Failed  int  `json:"failed"`
+	DryRun  bool `json:"dryRun"`
+}
+
+// ImportRowError tells why the row at Line was not imported.
+type ImportRowError struct {
+	Line    int               `json:"line"`
+	Id      int64             `json:"id,omitempty"`
+	Status  string            `json:"status"`
+	Message string            `json:"message"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
+
+type ImportBeersHandler struct {
+	repo      beer.Repository
+	validator echo.Validator
+}
+
+func NewImportBeersHandler(repo beer.Repository, validator echo.Validator) ImportBeersHandler {
+	if repo == nil {
+		panic("nil repo beer")
+	}
+
+	if validator == nil {
+		panic("nil validator")
+	}
+
+	return ImportBeersHandler{repo: repo, validator: validator}
+}
+
+type importRow struct {
+	line int
+	item CreateBeer
+}
+
+func (h ImportBeersHandler) Handle(ctx context.Context, command ImportBeers) (ImportReport, error) {
+	report := ImportReport{DryRun: command.DryRun}
+
+	if command.Mode == "" {
+		command.Mode = ImportInsert
+	}
+
+	if command.Mode != ImportInsert && command.Mode != ImportUpsert {
+		return report, errors.NewValidationError(map[string]string{
+			"mode": fmt.Sprintf("The mode must be %s or %s.", ImportInsert, ImportUpsert),
+		})
+	}
+
+	if command.OnError == nil {
+		command.OnError = func(ImportRowError) error { return nil }
+	}
+
+	seen := map[int64]bool{}
+	chunk := []importRow{}
+
+	for {
+		line, item, err := command.Source.Next()
+
+		if err == io.EOF {
+			break
+		}
+
+		report.Rows++
+
+		rowErr, ok := h.check(line, item, err, seen)
+
+		if ok {
+			seen[item.Id] = true
+			chunk = append(chunk, importRow{line: line, item: item})
+
+			if len(chunk) == importChunkSize {
+				if err := h.write(ctx, command, chunk, &report); err != nil {
+					return report, err
+				}
+
+				chunk = chunk[:0]
+			}
+
+			continue
+		}
+
+		if rowErr.Status == "" {
+			return report, err
+		}
+
+		report.Failed++
+
+		if err := command.OnError(rowErr); err != nil {
+			return report, err
+		}
+	}
+
+	if len(chunk) > 0 {
+		if err := h.write(ctx, command, chunk, &report); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// check reports whether a row read from the source can be imported. When it
+// cannot, the row error tells why, and it has no status when the error ends
+// the whole import.
+func (h ImportBeersHandler) check(line int, item CreateBeer, err error, seen map[int64]bool) (ImportRowError, bool) {
+	if err != nil {
+		appErr, ok := err.(errors.ApplicationError)
+
+		if !ok || appErr.ErrorType() != errors.ErrorTypeValidation {
+			return ImportRowError{}, false
+		}
+
+		return ImportRowError{Line: line, Status: StatusInvalid, Message: appErr.Error(), Errors: appErr.Errors()}, false
+	}
+
+	if invalid := h.validator.Validate(item); invalid != nil {
+		return invalidRow(line, item, invalid), false
+	}
+
+	if seen[item.Id] {
+		return ImportRowError{Line: line, Id: item.Id, Status: StatusConflict, Message: "The id appears earlier in the file."}, false
+	}
+
+	return ImportRowError{}, true
+}
+
+// write imports a chunk of valid rows, or only checks which ones exist on a
+// dry run.
+func (h ImportBeersHandler) write(ctx context.Context, command ImportBeers, chunk []importRow, report *ImportReport) error {
+	documents := []beer.Beer{}
+	now := time.Now()
+
+	for _, row := range chunk {
+		documents = append(documents, newBeer(row.item, now))
+	}
+
+	rowErrors := make([]error, len(chunk))
+	inserted := make([]bool, len(chunk))
+
+	switch {
+	case command.DryRun:
+		existing, err := h.existingIds(ctx, chunk)
+
+		if err != nil {
+			return err
+		}
+
+		for i, row := range chunk {
+			deleted, ok := existing[row.item.Id]
+			inserted[i] = !ok
+
+			if ok && (deleted || command.Mode == ImportInsert) {
+				rowErrors[i] = common.ErrDuplicateKey
+			}
+		}
+	case command.Mode == ImportUpsert:
+		result, err := h.repo.UpsertMany(ctx, documents, "import")
+
+		if err != nil {
+			return err
+		}
+
+		copy(rowErrors, result.Errors)
+		copy(inserted, result.Inserted)
+	default:
+		result, err := h.repo.InsertMany(ctx, documents, false)
+
+		if err != nil {
+			return err
+		}
+
+		copy(rowErrors, result.Errors)
+
+		for i := range inserted {
+			inserted[i] = true
+		}
+	}
+
+	for i, row := range chunk {
+		var rowErr ImportRowError
+
+		switch {
+		case rowErrors[i] == nil && inserted[i]:
+			report.Created++
+			continue
+		case rowErrors[i] == nil:
+			report.Updated++
+			continue
+		case errorsN.Is(rowErrors[i], common.ErrDuplicateKey):
+			rowErr = ImportRowError{Line: row.line, Id: row.item.Id, Status: StatusConflict, Message: "An element with the same id already exists."}
+		default:
+			rowErr = ImportRowError{Line: row.line, Id: row.item.Id, Status: StatusFailed, Message: "The beer could not be written."}
+		}
+
+		report.Failed++
+
+		if err := command.OnError(rowErr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// existingIds returns the ids of the chunk that are already taken, also by
+// soft deleted beers, and whether the beer holding each one is deleted.
+func (h ImportBeersHandler) existingIds(ctx context.Context, chunk []importRow) (map[int64]bool, error) {
+	ids := bson.A{}
+
+	for _, row := range chunk {
+		ids = append(ids, row.item.Id)
+	}
+
+	items, err := h.repo.Paginated(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}}, bson.D{{Key: "_id", Value: 1}}, int64(len(ids)), 0,
+		common.IncludeDeleted(), common.WithProjection(bson.D{{Key: "_id", Value: 1}, {Key: "deletedAt", Value: 1}}))
+
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[int64]bool{}
+
+	for _, item := range items {
+		existing[item.Id] = item.DeletedAt != nil
+	}
+
+	return existing, nil
+}
+
+func invalidRow(line int, item CreateBeer, err error) ImportRowError {
+	row := ImportRowError{Line: line, Id: item.Id, Status: StatusInvalid, Message: "The beer is not valid."}
+
+	if validationErrors, ok := err.(validator.ValidationErrors); ok {
+		row.Errors = validations.Simple(validationErrors)
+	}
+
+	return row
+}
@@ -0,0 +1,284 @@
+package command
+
+import (
+	"context"
+	errorsN "errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/common/mocks"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/juanmaabanto/go-ms-beers/internal/validations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testSourceRow struct {
+	item CreateBeer
+	err  error
+}
+
+type testSource struct {
+	rows []testSourceRow
+	next int
+}
+
+func (s *testSource) Next() (int, CreateBeer, error) {
+	if s.next == len(s.rows) {
+		return 0, CreateBeer{}, io.EOF
+	}
+
+	row := s.rows[s.next]
+	s.next++
+
+	return s.next, row.item, row.err
+}
+
+func newTestSource(items ...CreateBeer) *testSource {
+	source := &testSource{}
+
+	for _, item := range items {
+		source.rows = append(source.rows, testSourceRow{item: item})
+	}
+
+	return source
+}
+
+func Test_NewImportBeersHandler(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("panic")
+		}
+	}()
+
+	NewImportBeersHandler(nil, validations.NewValidationUtil())
+}
+
+func Test_NewImportBeersHandler_Nil_Validator(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("panic")
+		}
+	}()
+
+	NewImportBeersHandler(new(mocks.MockRepository[beer.Beer, int64]), nil)
+}
+
+func Test_Handle_ImportBeers_Invalid_Mode(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+
+	// Act
+	testCommand := NewImportBeersHandler(mockRepo, validations.NewValidationUtil())
+	_, err := testCommand.Handle(context.Background(), ImportBeers{Source: newTestSource(), Mode: "replace"})
+
+	// Assert
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Contains(t, err.(errors.ApplicationError).Errors(), "mode")
+}
+
+func Test_Handle_ImportBeers_Insert(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	invalid := validBeer(2)
+	invalid.Currency = ""
+	source := newTestSource(validBeer(1), invalid, validBeer(1), validBeer(3))
+	source.rows = append(source.rows, testSourceRow{err: errors.NewValidationError(map[string]string{"price": "The price must be a number."})})
+	rowErrors := []ImportRowError{}
+
+	mockRepo.On("InsertMany", ctx, mock.AnythingOfType("[]beer.Beer"), false).Return(common.InsertManyResult{
+		Errors: []error{nil, common.ErrDuplicateKey},
+	}, nil)
+
+	// Act
+	testCommand := NewImportBeersHandler(mockRepo, validations.NewValidationUtil())
+	report, err := testCommand.Handle(ctx, ImportBeers{
+		Source:  source,
+		OnError: func(row ImportRowError) error { rowErrors = append(rowErrors, row); return nil },
+	})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Len(t, mockRepo.Calls[0].Arguments.Get(1), 2)
+	assert.Equal(t, ImportReport{Rows: 5, Created: 1, Failed: 4}, report)
+	assert.Equal(t, []string{StatusInvalid, StatusConflict, StatusInvalid, StatusConflict}, []string{rowErrors[0].Status, rowErrors[1].Status, rowErrors[2].Status, rowErrors[3].Status})
+	assert.Equal(t, []int{2, 3, 5, 4}, []int{rowErrors[0].Line, rowErrors[1].Line, rowErrors[2].Line, rowErrors[3].Line})
+	assert.Contains(t, rowErrors[0].Errors, "Currency")
+	assert.Contains(t, rowErrors[2].Errors, "price")
+}
+
+func Test_Handle_ImportBeers_Upsert(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	mockRepo.On("UpsertMany", ctx, mock.AnythingOfType("[]beer.Beer"), "import").Return(common.UpsertManyResult{
+		Errors:   []error{nil, nil, errorsN.New("write failed")},
+		Inserted: []bool{true, false, false},
+	}, nil)
+
+	// Act
+	testCommand := NewImportBeersHandler(mockRepo, validations.NewValidationUtil())
+	report, err := testCommand.Handle(ctx, ImportBeers{Source: newTestSource(validBeer(1), validBeer(2), validBeer(3)), Mode: ImportUpsert})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "InsertMany")
+
+	assert.NoError(t, err)
+	assert.Equal(t, ImportReport{Rows: 3, Created: 1, Updated: 1, Failed: 1}, report)
+}
+
+func Test_Handle_ImportBeers_Upsert_Deleted(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	rowErrors := []ImportRowError{}
+
+	mockRepo.On("UpsertMany", ctx, mock.AnythingOfType("[]beer.Beer"), "import").Return(common.UpsertManyResult{
+		Errors:   []error{nil, common.ErrDuplicateKey},
+		Inserted: []bool{false, false},
+	}, nil)
+
+	// Act
+	testCommand := NewImportBeersHandler(mockRepo, validations.NewValidationUtil())
+	report, err := testCommand.Handle(ctx, ImportBeers{
+		Source:  newTestSource(validBeer(1), validBeer(2)),
+		Mode:    ImportUpsert,
+		OnError: func(row ImportRowError) error { rowErrors = append(rowErrors, row); return nil },
+	})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ImportReport{Rows: 2, Updated: 1, Failed: 1}, report)
+	assert.Equal(t, int64(2), rowErrors[0].Id)
+	assert.Equal(t, StatusConflict, rowErrors[0].Status)
+}
+
+func Test_Handle_ImportBeers_Dry_Run_Upsert_Deleted(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	rowErrors := []ImportRowError{}
+	now := time.Now()
+
+	updated := beer.Beer{}
+	updated.Id = 1
+	deleted := beer.Beer{}
+	deleted.Id = 2
+	deleted.DeletedAt = &now
+	mockRepo.On("Paginated", ctx, mock.Anything, mock.Anything, int64(3), int64(0), mock.AnythingOfType("[]common.ReadOption")).Return([]beer.Beer{updated, deleted}, nil)
+
+	// Act
+	testCommand := NewImportBeersHandler(mockRepo, validations.NewValidationUtil())
+	report, err := testCommand.Handle(ctx, ImportBeers{
+		Source:  newTestSource(validBeer(1), validBeer(2), validBeer(3)),
+		Mode:    ImportUpsert,
+		DryRun:  true,
+		OnError: func(row ImportRowError) error { rowErrors = append(rowErrors, row); return nil },
+	})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "UpsertMany")
+
+	assert.NoError(t, err)
+	assert.Equal(t, ImportReport{Rows: 3, Created: 1, Updated: 1, Failed: 1, DryRun: true}, report)
+	assert.Equal(t, int64(2), rowErrors[0].Id)
+	assert.Equal(t, StatusConflict, rowErrors[0].Status)
+}
+
+func Test_Handle_ImportBeers_Dry_Run(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	rowErrors := []ImportRowError{}
+
+	existing := beer.Beer{}
+	existing.Id = 2
+	mockRepo.On("Paginated", ctx, mock.Anything, mock.Anything, int64(2), int64(0), mock.AnythingOfType("[]common.ReadOption")).Return([]beer.Beer{existing}, nil)
+
+	// Act
+	testCommand := NewImportBeersHandler(mockRepo, validations.NewValidationUtil())
+	report, err := testCommand.Handle(ctx, ImportBeers{
+		Source:  newTestSource(validBeer(1), validBeer(2)),
+		DryRun:  true,
+		OnError: func(row ImportRowError) error { rowErrors = append(rowErrors, row); return nil },
+	})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "InsertMany")
+	mockRepo.AssertNotCalled(t, "UpsertMany")
+
+	opts := common.ReadOptions{}
+
+	for _, opt := range mockRepo.Calls[0].Arguments.Get(5).([]common.ReadOption) {
+		opt(&opts)
+	}
+
+	assert.NoError(t, err)
+	assert.True(t, opts.IncludeDeleted)
+	assert.Equal(t, ImportReport{Rows: 2, Created: 1, Failed: 1, DryRun: true}, report)
+	assert.Equal(t, int64(2), rowErrors[0].Id)
+	assert.Equal(t, StatusConflict, rowErrors[0].Status)
+}
+
+func Test_Handle_ImportBeers_Malformed_CSV_Row(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	file := "id,name,brewery,country,price,currency\n" +
+		"1,Pilsen,Backus,PE,3.50,PEN\n" +
+		"2,Cusque\"ña,Backus,PE,3.50,PEN\n" +
+		"3,Cristal,Backus,PE,3.00,PEN\n"
+	source, _ := NewBeerSource(FormatCSV, strings.NewReader(file), nil)
+	rowErrors := []ImportRowError{}
+
+	mockRepo.On("InsertMany", ctx, mock.AnythingOfType("[]beer.Beer"), false).Return(common.InsertManyResult{
+		Errors: []error{nil, nil},
+	}, nil)
+
+	// Act
+	testCommand := NewImportBeersHandler(mockRepo, validations.NewValidationUtil())
+	report, err := testCommand.Handle(ctx, ImportBeers{
+		Source:  source,
+		OnError: func(row ImportRowError) error { rowErrors = append(rowErrors, row); return nil },
+	})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ImportReport{Rows: 3, Created: 2, Failed: 1}, report)
+	assert.Len(t, rowErrors, 1)
+	assert.Equal(t, 3, rowErrors[0].Line)
+	assert.Equal(t, StatusInvalid, rowErrors[0].Status)
+	assert.Contains(t, rowErrors[0].Errors, "row")
+}
+
+func Test_Handle_ImportBeers_Source_Error(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	source := newTestSource(validBeer(1))
+	source.rows = append(source.rows, testSourceRow{err: errors.NewBadRequestError("The CSV file is malformed.")})
+
+	// Act
+	testCommand := NewImportBeersHandler(mockRepo, validations.NewValidationUtil())
+	_, err := testCommand.Handle(context.Background(), ImportBeers{Source: source})
+
+	// Assert
+	mockRepo.AssertNotCalled(t, "InsertMany")
+
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeBadRequest, err.(errors.ApplicationError).ErrorType())
+}
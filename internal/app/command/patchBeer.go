@@ -0,0 +1,140 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	errorsN "errors"
+
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/labstack/echo/v4"
+)
+
+// PatchBeer carries a JSON Merge Patch (RFC 7396) document for a beer.
+type PatchBeer struct {
+	Id       int64
+	Versions []int64
+	Patch    []byte
+}
+
+type PatchBeerHandler struct {
+	repo      beer.Repository
+	validator echo.Validator
+}
+
+func NewPatchBeerHandler(repo beer.Repository, validator echo.Validator) PatchBeerHandler {
+	if repo == nil {
+		panic("nil repo beer")
+	}
+
+	if validator == nil {
+		panic("nil validator")
+	}
+
+	return PatchBeerHandler{repo: repo, validator: validator}
+}
+
+// Handle applies the patch and returns the new version of the beer.
+func (h PatchBeerHandler) Handle(ctx context.Context, command PatchBeer) (int64, error) {
+	item, err := h.repo.FindById(ctx, command.Id)
+
+	if errorsN.Is(err, common.ErrNotFound) {
+		return 0, errors.NewNotFoundError("beer")
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if err := checkVersion(item.Version, command.Versions); err != nil {
+		return 0, err
+	}
+
+	current, err := json.Marshal(UpdateBeer{
+		Name:     item.Name,
+		Brewery:  item.Brewery,
+		Country:  item.Country,
+		Price:    item.Price,
+		Currency: item.Currency,
+		Abv:      item.Abv,
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	merged, err := mergePatch(current, command.Patch)
+
+	if err != nil {
+		return 0, errors.NewBadRequestError("The merge patch document is not valid JSON.")
+	}
+
+	update := UpdateBeer{}
+
+	err = json.Unmarshal(merged, &update)
+
+	if errorsN.Is(err, decimal.ErrOutOfRange) {
+		return 0, errors.NewValidationError(map[string]string{"body": decimalRangeMessage})
+	}
+
+	if err != nil {
+		return 0, errors.NewBadRequestError("The merge patch document does not describe a beer.")
+	}
+
+	update.Id = command.Id
+
+	if err := h.validator.Validate(update); err != nil {
+		return 0, err
+	}
+
+	return replaceBeer(ctx, h.repo, item, update)
+}
+
+// mergePatch applies patch to target following RFC 7396.
+func mergePatch(target []byte, patch []byte) ([]byte, error) {
+	var targetValue, patchValue interface{}
+
+	if err := decodeJSON(target, &targetValue); err != nil {
+		return nil, err
+	}
+
+	if err := decodeJSON(patch, &patchValue); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(mergeValue(targetValue, patchValue))
+}
+
+func mergeValue(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+		} else {
+			targetObject[key] = mergeValue(targetObject[key], value)
+		}
+	}
+
+	return targetObject
+}
+
+func decodeJSON(data []byte, receiver interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return decoder.Decode(receiver)
+}
@@ -0,0 +1,178 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-playground/validator"
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/common/mocks"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/juanmaabanto/go-ms-beers/internal/validations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_NewPatchBeerHandler(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("panic")
+		}
+	}()
+
+	NewPatchBeerHandler(nil, validations.NewValidationUtil())
+}
+
+func Test_NewPatchBeerHandler_Nil_Validator(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("panic")
+		}
+	}()
+
+	NewPatchBeerHandler(new(mocks.MockRepository[beer.Beer, int64]), nil)
+}
+
+func Test_Handle_PatchBeer_Not_Found(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	mockRepo.On("FindById", ctx, int64(1)).Return(nil, common.ErrNotFound)
+
+	// Act
+	testCommand := NewPatchBeerHandler(mockRepo, validations.NewValidationUtil())
+	_, err := testCommand.Handle(ctx, PatchBeer{Id: 1, Patch: []byte(`{"name":"test"}`)})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Error(t, err)
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeNotFound, err.(errors.ApplicationError).ErrorType())
+}
+
+func Test_Handle_PatchBeer_Invalid_Document(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+
+	// Act
+	testCommand := NewPatchBeerHandler(mockRepo, validations.NewValidationUtil())
+	_, err := testCommand.Handle(ctx, PatchBeer{Id: 1, Patch: []byte(`{"name":`)})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Error(t, err)
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeBadRequest, err.(errors.ApplicationError).ErrorType())
+}
+
+func Test_Handle_PatchBeer_Price_Out_Of_Range(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+
+	// Act
+	testCommand := NewPatchBeerHandler(mockRepo, validations.NewValidationUtil())
+	_, err := testCommand.Handle(ctx, PatchBeer{Id: 1, Patch: []byte(`{"price":"1e2000000000"}`)})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeValidation, err.(errors.ApplicationError).ErrorType())
+	assert.Contains(t, err.(errors.ApplicationError).Errors(), "body")
+}
+
+func Test_Handle_PatchBeer_Validation_Error(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	stored.Name = "test"
+	stored.Brewery = "brewery"
+	stored.Country = "Peru"
+	stored.Price = decimal.NewFromInt(10)
+	stored.Currency = "PEN"
+	mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+
+	// Act
+	testCommand := NewPatchBeerHandler(mockRepo, validations.NewValidationUtil())
+	_, err := testCommand.Handle(ctx, PatchBeer{Id: 1, Patch: []byte(`{"brewery":null}`)})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Error(t, err)
+	assert.IsType(t, validator.ValidationErrors{}, err)
+	assert.Equal(t, "Brewery", err.(validator.ValidationErrors)[0].Field())
+}
+
+func Test_Handle_PatchBeer_Completed(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	stored.Name = "test"
+	stored.Brewery = "brewery"
+	stored.Country = "Peru"
+	stored.Price = decimal.NewFromInt(10)
+	stored.Currency = "PEN"
+	mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+	mockRepo.On("UpdateOne", ctx, int64(1), int64(0), mock.AnythingOfType("beer.Beer")).Return(nil)
+
+	// Act
+	testCommand := NewPatchBeerHandler(mockRepo, validations.NewValidationUtil())
+	_, err := testCommand.Handle(ctx, PatchBeer{Id: 1, Patch: []byte(`{"price":12.5}`)})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+
+	updated := mockRepo.Calls[1].Arguments.Get(3).(beer.Beer)
+
+	assert.Equal(t, "test", updated.Name)
+	assert.Equal(t, "12.5", updated.Price.String())
+	assert.NotNil(t, updated.ModifiedAt)
+}
+
+func Test_mergePatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		patch  string
+		want   string
+	}{
+		{name: "replace member", target: `{"a":"b"}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		{name: "add member", target: `{"a":"b"}`, patch: `{"b":"c"}`, want: `{"a":"b","b":"c"}`},
+		{name: "remove member", target: `{"a":"b"}`, patch: `{"a":null}`, want: `{}`},
+		{name: "nested object", target: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, want: `{"a":{"b":"d"}}`},
+		{name: "array replaced", target: `{"a":["b"]}`, patch: `{"a":["c","d"]}`, want: `{"a":["c","d"]}`},
+		{name: "non object patch", target: `{"a":"b"}`, patch: `["c"]`, want: `["c"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergePatch([]byte(tt.target), []byte(tt.patch))
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
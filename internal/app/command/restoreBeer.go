@@ -0,0 +1,46 @@
+package command
+
+import (
+	"context"
+	errorsN "errors"
+
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+)
+
+type RestoreBeer struct {
+	Id int64
+}
+
+type RestoreBeerHandler struct {
+	repo beer.Repository
+}
+
+func NewRestoreBeerHandler(repo beer.Repository) RestoreBeerHandler {
+	if repo == nil {
+		panic("nil repo beer")
+	}
+
+	return RestoreBeerHandler{repo: repo}
+}
+
+func (h RestoreBeerHandler) Handle(ctx context.Context, command RestoreBeer) error {
+	item, err := h.repo.FindById(ctx, command.Id, common.IncludeDeleted())
+
+	if errorsN.Is(err, common.ErrNotFound) {
+		return errors.NewNotFoundError("beer")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if item.DeletedAt == nil {
+		return errors.NewConflictError("The beer is not deleted.")
+	}
+
+	_, err = h.repo.RestoreById(ctx, command.Id, "admin")
+
+	return err
+}
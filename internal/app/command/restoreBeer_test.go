@@ -0,0 +1,86 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/common/mocks"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_NewRestoreBeerHandler(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("panic")
+		}
+	}()
+
+	NewRestoreBeerHandler(nil)
+}
+
+func Test_Handle_RestoreBeer_Not_Found(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	mockRepo.On("FindById", ctx, int64(1), mock.AnythingOfType("[]common.ReadOption")).Return(nil, common.ErrNotFound)
+
+	// Act
+	testCommand := NewRestoreBeerHandler(mockRepo)
+	err := testCommand.Handle(ctx, RestoreBeer{Id: 1})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Error(t, err)
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeNotFound, err.(errors.ApplicationError).ErrorType())
+}
+
+func Test_Handle_RestoreBeer_Not_Deleted(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	mockRepo.On("FindById", ctx, int64(1), mock.AnythingOfType("[]common.ReadOption")).Return(stored, nil)
+
+	// Act
+	testCommand := NewRestoreBeerHandler(mockRepo)
+	err := testCommand.Handle(ctx, RestoreBeer{Id: 1})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Error(t, err)
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeConflict, err.(errors.ApplicationError).ErrorType())
+}
+
+func Test_Handle_RestoreBeer_Restored(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	deletedAt := time.Now()
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	stored.DeletedAt = &deletedAt
+	mockRepo.On("FindById", ctx, int64(1), mock.AnythingOfType("[]common.ReadOption")).Return(stored, nil)
+	mockRepo.On("RestoreById", ctx, int64(1), "admin").Return(int64(1), nil)
+
+	// Act
+	testCommand := NewRestoreBeerHandler(mockRepo)
+	err := testCommand.Handle(ctx, RestoreBeer{Id: 1})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+}
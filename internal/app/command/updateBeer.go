@@ -0,0 +1,90 @@
+package command
+
+import (
+	"context"
+	errorsN "errors"
+	"time"
+
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+)
+
+type UpdateBeer struct {
+	Id       int64           `json:"-"`
+	Versions []int64         `json:"-"`
+	Name     string          `json:"name" validate:"required,max=30"`
+	Brewery  string          `json:"brewery" validate:"required,max=30"`
+	Country  string          `json:"country" validate:"required,max=20"`
+	Price    decimal.Decimal `json:"price" validate:"required" swaggertype:"number"`
+	Currency string          `json:"currency" validate:"required,max=5"`
+	Abv      decimal.Decimal `json:"abv" validate:"gte=0,lte=100" swaggertype:"number"`
+}
+
+type UpdateBeerHandler struct {
+	repo beer.Repository
+}
+
+func NewUpdateBeerHandler(repo beer.Repository) UpdateBeerHandler {
+	if repo == nil {
+		panic("nil repo beer")
+	}
+
+	return UpdateBeerHandler{repo: repo}
+}
+
+// Handle replaces the beer and returns its new version.
+func (h UpdateBeerHandler) Handle(ctx context.Context, command UpdateBeer) (int64, error) {
+	item, err := h.repo.FindById(ctx, command.Id)
+
+	if errorsN.Is(err, common.ErrNotFound) {
+		return 0, errors.NewNotFoundError("beer")
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if err := checkVersion(item.Version, command.Versions); err != nil {
+		return 0, err
+	}
+
+	return replaceBeer(ctx, h.repo, item, command)
+}
+
+// checkVersion fails when the caller expects specific versions (If-Match) and
+// the current one is not among them. A nil list means no expectation.
+func checkVersion(current int64, expected []int64) error {
+	if expected == nil {
+		return nil
+	}
+
+	for _, version := range expected {
+		if version == current {
+			return nil
+		}
+	}
+
+	return errors.NewPreconditionFailedError("The beer has been modified since it was read.")
+}
+
+func replaceBeer(ctx context.Context, repo beer.Repository, item beer.Beer, command UpdateBeer) (int64, error) {
+	modifiedAt := time.Now()
+	modifiedBy := "admin"
+
+	item.Name = command.Name
+	item.Brewery = command.Brewery
+	item.Country = command.Country
+	item.Price = command.Price
+	item.Currency = command.Currency
+	item.Abv = command.Abv
+	item.ModifiedAt = &modifiedAt
+	item.ModifiedBy = &modifiedBy
+
+	if err := repo.UpdateOne(ctx, item.Id, item.Version, item); err != nil {
+		return 0, err
+	}
+
+	return item.Version + 1, nil
+}
@@ -0,0 +1,165 @@
+package command
+
+import (
+	"context"
+	errorsN "errors"
+	"testing"
+
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/common/mocks"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_NewUpdateBeerHandler(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("panic")
+		}
+	}()
+
+	NewUpdateBeerHandler(nil)
+}
+
+func Test_Handle_UpdateBeer_FindById_Error(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	item := UpdateBeer{Id: 1, Name: "test"}
+
+	mockRepo.On("FindById", ctx, int64(1)).Return(nil, errorsN.New("An error has occurred"))
+
+	// Act
+	testCommand := NewUpdateBeerHandler(mockRepo)
+	_, err := testCommand.Handle(ctx, item)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Error(t, err)
+	assert.Equal(t, err.Error(), "An error has occurred")
+}
+
+func Test_Handle_UpdateBeer_Not_Found(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	item := UpdateBeer{Id: 1, Name: "test"}
+
+	mockRepo.On("FindById", ctx, int64(1)).Return(nil, common.ErrNotFound)
+
+	// Act
+	testCommand := NewUpdateBeerHandler(mockRepo)
+	_, err := testCommand.Handle(ctx, item)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Error(t, err)
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeNotFound, err.(errors.ApplicationError).ErrorType())
+}
+
+func Test_Handle_UpdateBeer_Completed(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	item := UpdateBeer{Id: 1, Name: "new name", Brewery: "brewery", Country: "Peru", Price: decimal.NewFromInt(10), Currency: "PEN"}
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	stored.Name = "old name"
+	stored.CreatedBy = "creator"
+	mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+	mockRepo.On("UpdateOne", ctx, int64(1), int64(0), mock.AnythingOfType("beer.Beer")).Return(nil)
+
+	// Act
+	testCommand := NewUpdateBeerHandler(mockRepo)
+	version, err := testCommand.Handle(ctx, item)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+
+	updated := mockRepo.Calls[1].Arguments.Get(3).(beer.Beer)
+
+	assert.Equal(t, "new name", updated.Name)
+	assert.Equal(t, "creator", updated.CreatedBy)
+	assert.NotNil(t, updated.ModifiedAt)
+	assert.NotNil(t, updated.ModifiedBy)
+}
+
+func Test_Handle_UpdateBeer_Precondition_Failed(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	item := UpdateBeer{Id: 1, Versions: []int64{2}, Name: "test"}
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	stored.Version = 3
+	mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+
+	// Act
+	testCommand := NewUpdateBeerHandler(mockRepo)
+	_, err := testCommand.Handle(ctx, item)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "UpdateOne", ctx, int64(1), int64(3), mock.AnythingOfType("beer.Beer"))
+
+	assert.Error(t, err)
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypePreconditionFailed, err.(errors.ApplicationError).ErrorType())
+}
+
+func Test_Handle_UpdateBeer_Conflict(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	item := UpdateBeer{Id: 1, Versions: []int64{3}, Name: "test"}
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	stored.Version = 3
+	mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+	mockRepo.On("UpdateOne", ctx, int64(1), int64(3), mock.AnythingOfType("beer.Beer")).Return(errors.NewConflictError("conflict"))
+
+	// Act
+	testCommand := NewUpdateBeerHandler(mockRepo)
+	_, err := testCommand.Handle(ctx, item)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Error(t, err)
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeConflict, err.(errors.ApplicationError).ErrorType())
+}
+
+func Test_Handle_UpdateBeer_UpdateOne_Error(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	item := UpdateBeer{Id: 1, Name: "test"}
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+	mockRepo.On("UpdateOne", ctx, int64(1), int64(0), mock.AnythingOfType("beer.Beer")).Return(errorsN.New("An error has occurred"))
+
+	// Act
+	testCommand := NewUpdateBeerHandler(mockRepo)
+	_, err := testCommand.Handle(ctx, item)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Error(t, err)
+	assert.Equal(t, err.Error(), "An error has occurred")
+}
@@ -0,0 +1,55 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// selectableFields maps the fields of response.BeerResponse accepted by the
+// fields parameter to the document fields.
+var selectableFields = map[string]string{
+	"id":       "_id",
+	"name":     "name",
+	"brewery":  "brewery",
+	"country":  "country",
+	"price":    "price",
+	"currency": "currency",
+	"abv":      "abv",
+	"version":  "version",
+}
+
+// fieldsOptions returns the projection that reads only the requested fields,
+// plus the document fields the handler needs itself. Without requested fields
+// the whole beer is read.
+func fieldsOptions(fields []string, needed ...string) ([]common.ReadOption, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	read := []string{}
+
+	for _, name := range fields {
+		field, ok := selectableFields[name]
+
+		if !ok {
+			return nil, errors.NewBadRequestError(fmt.Sprintf("The beers have no field %q.", name))
+		}
+
+		read = append(read, field)
+	}
+
+	projection := bson.D{}
+	seen := map[string]bool{}
+
+	for _, field := range append(read, needed...) {
+		if !seen[field] {
+			seen[field] = true
+			projection = append(projection, bson.E{Key: field, Value: 1})
+		}
+	}
+
+	return []common.ReadOption{common.WithProjection(projection)}, nil
+}
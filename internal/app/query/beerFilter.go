@@ -0,0 +1,168 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxFilterValues bounds the values accepted for a single field.
+const maxFilterValues = 20
+
+// maxNameLength bounds the text searched in the names.
+const maxNameLength = 100
+
+// Ways of matching the name. The text is always taken literally, only
+// SearchText gives meaning to words and quotes.
+const (
+	// SearchContains matches names containing the text, ignoring case.
+	SearchContains = "contains"
+	// SearchPrefix matches names starting with the text, for autocomplete.
+	SearchPrefix = "prefix"
+	// SearchText uses the text index and ranks beers by relevance.
+	SearchText = "text"
+)
+
+// BeerFilter selects beers. Every criterion that is set must match, and a
+// criterion with several values matches any of them. Breweries and countries
+// are compared ignoring case.
+type BeerFilter struct {
+	Name       string
+	Search     string
+	Breweries  []string
+	Countries  []string
+	Currencies []string
+	MinPrice   *decimal.Decimal
+	MaxPrice   *decimal.Decimal
+}
+
+func (f BeerFilter) validate() error {
+	errs := map[string]string{}
+
+	switch f.Search {
+	case "", SearchContains, SearchPrefix:
+	case SearchText:
+		if strings.TrimSpace(f.Name) == "" {
+			errs["name"] = "A name is required to search by text."
+		}
+	default:
+		errs["search"] = fmt.Sprintf("The search must be one of %s, %s or %s.", SearchContains, SearchPrefix, SearchText)
+	}
+
+	if utf8.RuneCountInString(f.Name) > maxNameLength {
+		errs["name"] = fmt.Sprintf("The name cannot be longer than %d characters.", maxNameLength)
+	}
+
+	for field, values := range map[string][]string{"brewery": f.Breweries, "country": f.Countries, "currency": f.Currencies} {
+		if len(values) > maxFilterValues {
+			errs[field] = fmt.Sprintf("At most %d values are allowed.", maxFilterValues)
+		}
+	}
+
+	for _, code := range f.Currencies {
+		if !currency.IsKnown(currency.Normalize(code)) {
+			errs["currency"] = fmt.Sprintf("%s is not a valid ISO 4217 currency code.", code)
+		}
+	}
+
+	if f.MinPrice != nil && f.MinPrice.Sign() < 0 {
+		errs["minPrice"] = "The minimum price cannot be negative."
+	}
+
+	if f.MaxPrice != nil && f.MaxPrice.Sign() < 0 {
+		errs["maxPrice"] = "The maximum price cannot be negative."
+	}
+
+	if f.MinPrice != nil && f.MaxPrice != nil && f.MinPrice.Cmp(*f.MaxPrice) > 0 {
+		errs["minPrice"] = "The minimum price cannot be greater than the maximum price."
+	}
+
+	if len(errs) > 0 {
+		return errors.NewValidationError(errs)
+	}
+
+	return nil
+}
+
+// document returns the MongoDB filter, shared by the count and the page so
+// that both always agree.
+func (f BeerFilter) document() bson.D {
+	conditions := bson.A{}
+
+	if f.Name != "" {
+		conditions = append(conditions, f.nameCondition())
+	}
+
+	if len(f.Breweries) > 0 {
+		conditions = append(conditions, bson.D{{Key: "brewery", Value: bson.D{{Key: "$in", Value: exactIgnoringCase(f.Breweries)}}}})
+	}
+
+	if len(f.Countries) > 0 {
+		conditions = append(conditions, bson.D{{Key: "country", Value: bson.D{{Key: "$in", Value: exactIgnoringCase(f.Countries)}}}})
+	}
+
+	if len(f.Currencies) > 0 {
+		codes := bson.A{}
+
+		for _, code := range f.Currencies {
+			codes = append(codes, currency.Normalize(code))
+		}
+
+		conditions = append(conditions, bson.D{{Key: "currency", Value: bson.D{{Key: "$in", Value: codes}}}})
+	}
+
+	price := bson.D{}
+
+	if f.MinPrice != nil {
+		price = append(price, bson.E{Key: "$gte", Value: *f.MinPrice})
+	}
+
+	if f.MaxPrice != nil {
+		price = append(price, bson.E{Key: "$lte", Value: *f.MaxPrice})
+	}
+
+	if len(price) > 0 {
+		conditions = append(conditions, bson.D{{Key: "price", Value: price}})
+	}
+
+	if len(conditions) == 0 {
+		return bson.D{}
+	}
+
+	return bson.D{{Key: "$and", Value: conditions}}
+}
+
+// rankedByRelevance reports whether the beers found have a text score.
+func (f BeerFilter) rankedByRelevance() bool {
+	return f.Search == SearchText && f.Name != ""
+}
+
+// nameCondition matches the name. The regular expressions escape the text, so
+// a name such as "Lager (6+)" is searched as written.
+func (f BeerFilter) nameCondition() bson.D {
+	switch f.Search {
+	case SearchText:
+		return bson.D{{Key: "$text", Value: bson.D{{Key: "$search", Value: f.Name}}}}
+	case SearchPrefix:
+		return bson.D{{Key: "name", Value: primitive.Regex{Pattern: "^" + regexp.QuoteMeta(f.Name), Options: "i"}}}
+	default:
+		return bson.D{{Key: "name", Value: primitive.Regex{Pattern: regexp.QuoteMeta(f.Name), Options: "i"}}}
+	}
+}
+
+func exactIgnoringCase(values []string) bson.A {
+	patterns := bson.A{}
+
+	for _, value := range values {
+		patterns = append(patterns, primitive.Regex{Pattern: "^" + regexp.QuoteMeta(value) + "$", Options: "i"})
+	}
+
+	return patterns
+}
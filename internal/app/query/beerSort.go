@@ -0,0 +1,84 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// sortableFields maps the fields accepted by the sort parameter to the
+// document fields.
+var sortableFields = map[string]string{
+	"id":       "_id",
+	"name":     "name",
+	"brewery":  "brewery",
+	"country":  "country",
+	"price":    "price",
+	"currency": "currency",
+	"abv":      "abv",
+}
+
+// sortDocument turns a sort such as "-price,name" into a MongoDB sort. A
+// leading "-" sorts descending. The id is always the last key, so beers with
+// the same values keep their order between pages.
+func sortDocument(sort string) (bson.D, error) {
+	document := bson.D{}
+	seen := map[string]bool{}
+
+	for _, item := range strings.Split(sort, ",") {
+		item = strings.TrimSpace(item)
+
+		if item == "" {
+			continue
+		}
+
+		direction := 1
+
+		if strings.HasPrefix(item, "-") {
+			direction = -1
+			item = item[1:]
+		}
+
+		field, ok := sortableFields[item]
+
+		if !ok {
+			return nil, errors.NewBadRequestError(fmt.Sprintf("The beers cannot be sorted by %q.", item))
+		}
+
+		if seen[field] {
+			return nil, errors.NewBadRequestError(fmt.Sprintf("The field %q appears twice in the sort.", item))
+		}
+
+		seen[field] = true
+		document = append(document, bson.E{Key: field, Value: direction})
+	}
+
+	if !seen["_id"] {
+		document = append(document, bson.E{Key: "_id", Value: 1})
+	}
+
+	return document, nil
+}
+
+// relevanceSort puts the best text matches first, then the beers with the same
+// score by id.
+func relevanceSort() bson.D {
+	return bson.D{
+		{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}},
+		{Key: "_id", Value: 1},
+	}
+}
+
+// sortSignature identifies a sort, so a cursor is only used with the sort it
+// was issued for.
+func sortSignature(sort bson.D) string {
+	fields := []string{}
+
+	for _, field := range sort {
+		fields = append(fields, fmt.Sprintf("%s:%v", field.Key, field.Value))
+	}
+
+	return strings.Join(fields, ",")
+}
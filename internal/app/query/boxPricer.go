@@ -0,0 +1,220 @@
+package query
+
+import (
+	"context"
+	errorsN "errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/money"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/pricing"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/tax"
+	"github.com/juanmaabanto/go-ms-beers/internal/ports/response"
+)
+
+// boxPricer prices boxes of beer for the box price and quote queries: it
+// checks the box size, converts the price and applies the discounts and the
+// taxes.
+type boxPricer struct {
+	rates currency.ExchangeRateProvider
+	rules pricing.Rules
+	taxes tax.Rules
+}
+
+// taxLines returns the normalized destination with its tax lines. An empty
+// destination has no taxes.
+func (p boxPricer) taxLines(destination string) (string, []tax.Line, error) {
+	destination = strings.ToUpper(strings.TrimSpace(destination))
+	lines, ok := p.taxes.Lines(destination)
+
+	if len(destination) > 0 && !ok {
+		return "", nil, errors.NewValidationError(map[string]string{
+			"destination": fmt.Sprintf("There are no tax rules for the destination %s.", destination),
+		})
+	}
+
+	return destination, lines, nil
+}
+
+// rate returns the rate from source to target, or nil when no conversion is
+// needed.
+func (p boxPricer) rate(ctx context.Context, source string, target string) (*currency.Rate, error) {
+	if len(target) == 0 || target == source {
+		return nil, nil
+	}
+
+	rate, err := p.rates.GetRate(ctx, source, target)
+
+	if err != nil {
+		return nil, rateError(err)
+	}
+
+	return &rate, nil
+}
+
+// rateCache holds the rates fetched while answering a query, or why they could
+// not be fetched, by source and target currency.
+type rateCache map[string]cachedRate
+
+type cachedRate struct {
+	rate *currency.Rate
+	err  error
+}
+
+// cachedRate returns the rate from source to target, fetching every pair at
+// most once per cache.
+func (p boxPricer) cachedRate(ctx context.Context, rates rateCache, source string, target string) (*currency.Rate, error) {
+	key := source + "/" + target
+
+	if cached, ok := rates[key]; ok {
+		return cached.rate, cached.err
+	}
+
+	rate, err := p.rate(ctx, source, target)
+	rates[key] = cachedRate{rate, err}
+
+	return rate, err
+}
+
+// price prices a box of the beer in the target currency. The rates of the
+// beer and of the excise duties are taken from rates, and fetched into it when
+// missing.
+func (p boxPricer) price(ctx context.Context, item beer.Beer, target string, quantity int64, taxLines []tax.Line, rates rateCache) (pricing.Breakdown, error) {
+	product := pricing.Product{
+		BeerId:  item.Id,
+		Brewery: item.Brewery,
+		Country: item.Country,
+	}
+
+	if !p.rules.Allows(product, quantity) {
+		return pricing.Breakdown{}, quantityError(fmt.Sprintf("The quantity must be one of %s.", formatSizes(p.rules.BoxSizes(product))))
+	}
+
+	rate, err := p.cachedRate(ctx, rates, item.Currency, target)
+
+	if err != nil {
+		return pricing.Breakdown{}, err
+	}
+
+	breakdown, err := pricing.NewBreakdown(item.UnitPrice(), rate, quantity)
+
+	if err != nil {
+		return pricing.Breakdown{}, err
+	}
+
+	if tier, ok := p.rules.Discount(product, quantity); ok {
+		if err := breakdown.ApplyDiscount(tier); err != nil {
+			return pricing.Breakdown{}, err
+		}
+	}
+
+	convert := func(amount money.Money, target string) (money.Money, error) {
+		rate, err := p.cachedRate(ctx, rates, amount.Currency, target)
+
+		if err != nil || rate == nil {
+			return amount, err
+		}
+
+		return amount.Convert(*rate)
+	}
+
+	charges, err := tax.Compute(taxLines, breakdown.Net(), breakdown.Quantity, item.Abv, convert)
+
+	if err != nil {
+		return pricing.Breakdown{}, err
+	}
+
+	for _, charge := range charges {
+		breakdown.Taxes = append(breakdown.Taxes, pricing.Adjustment{Name: charge.Name, Amount: charge.Amount})
+	}
+
+	return breakdown, nil
+}
+
+func newPriceResponse(breakdown pricing.Breakdown) response.PriceResponse {
+	total := breakdown.Total()
+
+	result := response.PriceResponse{
+		PriceTotal:         total.Amount,
+		Currency:           total.Currency,
+		UnitPrice:          newMoneyResponse(breakdown.UnitPrice),
+		ConvertedUnitPrice: newMoneyResponse(breakdown.ConvertedUnitPrice),
+		Quantity:           breakdown.Quantity,
+		Subtotal:           breakdown.Subtotal.Amount,
+		Discounts:          newAdjustmentResponses(breakdown.Discounts),
+		Taxes:              newAdjustmentResponses(breakdown.Taxes),
+		Total:              total.Amount,
+	}
+
+	if breakdown.Rate != nil {
+		result.ExchangeRate = &response.RateResponse{
+			Source:    breakdown.Rate.Source,
+			Target:    breakdown.Rate.Target,
+			Value:     breakdown.Rate.Value,
+			Timestamp: breakdown.Rate.Timestamp,
+			Provider:  breakdown.Rate.Provider,
+		}
+	}
+
+	return result
+}
+
+func newMoneyResponse(m money.Money) response.MoneyResponse {
+	return response.MoneyResponse{Amount: m.Amount, Currency: m.Currency}
+}
+
+func newAdjustmentResponses(adjustments []pricing.Adjustment) []response.AdjustmentResponse {
+	items := make([]response.AdjustmentResponse, 0, len(adjustments))
+
+	for _, adjustment := range adjustments {
+		items = append(items, response.AdjustmentResponse{
+			Name:   adjustment.Name,
+			Amount: adjustment.Amount.Amount,
+		})
+	}
+
+	return items
+}
+
+func quantityError(message string) error {
+	return errors.NewValidationError(map[string]string{"quantity": message})
+}
+
+func formatSizes(sizes []int64) string {
+	items := make([]string, len(sizes))
+
+	for i, size := range sizes {
+		items[i] = strconv.FormatInt(size, 10)
+	}
+
+	return strings.Join(items, ", ")
+}
+
+func unknownCurrencyError(code string) error {
+	return errors.NewValidationError(map[string]string{
+		"currency": fmt.Sprintf("%s is not a valid ISO 4217 currency code.", code),
+	})
+}
+
+// rateError turns an exchange rate failure into the error returned to the
+// client, so that a price is never computed from a missing rate.
+func rateError(err error) error {
+	switch {
+	case errorsN.Is(err, currency.ErrUnknownCurrency):
+		return errors.NewValidationError(map[string]string{
+			"currency": "The currency is not supported by the exchange rate service.",
+		})
+	case errorsN.Is(err, currency.ErrMissingQuote):
+		return errors.NewBadGatewayError("The exchange rate service has no quote for the currency.")
+	case errorsN.Is(err, currency.ErrMalformedPayload):
+		return errors.NewBadGatewayError("The exchange rate service returned an invalid response.")
+	case errorsN.Is(err, currency.ErrUnavailable):
+		return errors.NewServiceUnavailableError("The exchange rate service is unavailable, please try again later.")
+	default:
+		return err
+	}
+}
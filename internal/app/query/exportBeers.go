@@ -0,0 +1,63 @@
+package query
+
+import (
+	"context"
+	"strings"
+
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/juanmaabanto/go-ms-beers/internal/ports/response"
+)
+
+// exportBatchSize is the number of beers an export fetches at a time.
+const exportBatchSize = 500
+
+// ExportBeers reads every beer matching the filter, in the order of Sort as
+// in ListBeers.
+type ExportBeers struct {
+	BeerFilter
+	Sort string
+}
+
+type ExportBeersHandler struct {
+	repo beer.Repository
+}
+
+func NewExportBeersHandler(repo beer.Repository) ExportBeersHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	return ExportBeersHandler{repo}
+}
+
+// Handle passes the beers to write one at a time as they are read, so the
+// catalogue is never held in memory. It stops at the first error of write.
+func (h ExportBeersHandler) Handle(ctx context.Context, query ExportBeers, write func(response.BeerResponse) error) error {
+	if err := query.validate(); err != nil {
+		return err
+	}
+
+	sort, err := sortDocument(query.Sort)
+
+	if err != nil {
+		return err
+	}
+
+	if query.rankedByRelevance() && strings.TrimSpace(query.Sort) == "" {
+		sort = relevanceSort()
+	}
+
+	return h.repo.FilterBy(ctx, query.document(), sort, func(element beer.Beer) error {
+		return write(response.BeerResponse{
+			Id:       element.Id,
+			Name:     element.Name,
+			Brewery:  element.Brewery,
+			Country:  element.Country,
+			Price:    element.Price,
+			Currency: element.Currency,
+			Abv:      element.Abv,
+			Version:  element.Version,
+		})
+	}, common.WithBatchSize(exportBatchSize))
+}
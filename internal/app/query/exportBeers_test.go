@@ -0,0 +1,103 @@
+package query
+
+import (
+	"context"
+	errorsN "errors"
+	"testing"
+
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/common/mocks"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/juanmaabanto/go-ms-beers/internal/ports/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func Test_NewExportBeersHandler(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("panic")
+		}
+	}()
+
+	NewExportBeersHandler(nil)
+}
+
+func Test_Handle_ExportBeers_Ok(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	first, second := beer.Beer{}, beer.Beer{}
+	first.Id, first.Name = 1, "Pilsen"
+	second.Id, second.Name = 2, "Cusqueña"
+	filter := BeerFilter{Countries: []string{"PE"}}
+	items := []response.BeerResponse{}
+
+	mockRepo.On("FilterBy", ctx, filter.document(), bson.D{{Key: "name", Value: -1}, {Key: "_id", Value: 1}}, mock.Anything, mock.AnythingOfType("[]common.ReadOption")).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(3).(func(beer.Beer) error)
+			fn(first)
+			fn(second)
+		}).Return(nil)
+
+	// Act
+	testQuery := NewExportBeersHandler(mockRepo)
+	err := testQuery.Handle(ctx, ExportBeers{BeerFilter: filter, Sort: "-name"}, func(item response.BeerResponse) error {
+		items = append(items, item)
+		return nil
+	})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	options := common.ReadOptions{}
+
+	for _, opt := range mockRepo.Calls[0].Arguments.Get(4).([]common.ReadOption) {
+		opt(&options)
+	}
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(exportBatchSize), options.BatchSize)
+	assert.Len(t, items, 2)
+	assert.Equal(t, int64(1), items[0].Id)
+	assert.Equal(t, "Pilsen", items[0].Name)
+	assert.Equal(t, int64(2), items[1].Id)
+	assert.Equal(t, "Cusqueña", items[1].Name)
+}
+
+func Test_Handle_ExportBeers_Write_Error(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	writeErr := errorsN.New("client gone")
+	var filterErr error
+
+	mockRepo.On("FilterBy", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			filterErr = args.Get(3).(func(beer.Beer) error)(beer.Beer{})
+		}).Return(writeErr)
+
+	// Act
+	testQuery := NewExportBeersHandler(mockRepo)
+	err := testQuery.Handle(ctx, ExportBeers{}, func(response.BeerResponse) error { return writeErr })
+
+	// Assert
+	assert.ErrorIs(t, filterErr, writeErr)
+	assert.ErrorIs(t, err, writeErr)
+}
+
+func Test_Handle_ExportBeers_Invalid_Sort(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+
+	// Act
+	testQuery := NewExportBeersHandler(mockRepo)
+	err := testQuery.Handle(context.Background(), ExportBeers{Sort: "color"}, func(response.BeerResponse) error { return nil })
+
+	// Assert
+	mockRepo.AssertNotCalled(t, "FilterBy")
+
+	assert.IsType(t, errors.ApplicationError{}, err)
+}
@@ -2,14 +2,19 @@ package query
 
 import (
 	"context"
+	errorsN "errors"
 
+	"github.com/juanmaabanto/go-ms-beers/common"
 	"github.com/juanmaabanto/go-ms-beers/common/errors"
 	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
 	"github.com/juanmaabanto/go-ms-beers/internal/ports/response"
 )
 
+// GetBeerById reads the beer with Id. When Fields is set only those fields of
+// response.BeerResponse are read, the others keep their zero value.
 type GetBeerById struct {
-	Id int64
+	Id     int64
+	Fields []string
 }
 
 type GetBeerByIdHandler struct {
@@ -25,18 +30,22 @@ func NewGetBeerByIdHandler(repo beer.Repository) GetBeerByIdHandler {
 }
 
 func (h GetBeerByIdHandler) Handle(ctx context.Context, query GetBeerById) (*response.BeerResponse, error) {
-	receiver := beer.Beer{}
-
-	err := h.repo.FindById(ctx, query.Id, &receiver)
+	opts, err := fieldsOptions(query.Fields, "version")
 
 	if err != nil {
 		return nil, err
 	}
 
-	if receiver.Id == 0 {
+	receiver, err := h.repo.FindById(ctx, query.Id, opts...)
+
+	if errorsN.Is(err, common.ErrNotFound) {
 		return nil, errors.NewNotFoundError("beer")
 	}
 
+	if err != nil {
+		return nil, err
+	}
+
 	response := response.BeerResponse{
 		Id:       receiver.Id,
 		Name:     receiver.Name,
@@ -44,6 +53,8 @@ func (h GetBeerByIdHandler) Handle(ctx context.Context, query GetBeerById) (*res
 		Country:  receiver.Country,
 		Price:    receiver.Price,
 		Currency: receiver.Currency,
+		Abv:      receiver.Abv,
+		Version:  receiver.Version,
 	}
 
 	return &response, nil
@@ -3,14 +3,18 @@ package query
 import (
 	"context"
 	errorsN "errors"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/juanmaabanto/go-ms-beers/common"
 	"github.com/juanmaabanto/go-ms-beers/common/errors"
 	"github.com/juanmaabanto/go-ms-beers/common/mocks"
 	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
 	"github.com/juanmaabanto/go-ms-beers/internal/ports/response"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func Test_NewGetBeerByIdHandler(t *testing.T) {
@@ -25,15 +29,14 @@ func Test_NewGetBeerByIdHandler(t *testing.T) {
 
 func Test_Handle_GetBeerById_Found(t *testing.T) {
 	// Arrange
-	mockRepo := new(mocks.MockRepository)
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
 	ctx := context.Background()
 	expected := response.BeerResponse{Id: 1, Name: "test"}
 
-	mockRepo.On("FindById", ctx, expected.Id, mock.AnythingOfType("*beer.Beer")).Return(nil).Run(func(args mock.Arguments) {
-		arg := args.Get(2).(*beer.Beer)
-		arg.Id = expected.Id
-		arg.Name = expected.Name
-	})
+	stored := beer.Beer{}
+	stored.Id = expected.Id
+	stored.Name = expected.Name
+	mockRepo.On("FindById", ctx, expected.Id).Return(stored, nil)
 
 	// Act
 	testQuery := NewGetBeerByIdHandler(mockRepo)
@@ -49,13 +52,10 @@ func Test_Handle_GetBeerById_Found(t *testing.T) {
 
 func Test_Handler_GetBeerById_Not_Found(t *testing.T) {
 	// Arrange
-	mockRepo := new(mocks.MockRepository)
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
 	ctx := context.Background()
 
-	mockRepo.On("FindById", ctx, mock.AnythingOfType("int64"), mock.AnythingOfType("*beer.Beer")).Return(nil).Run(func(args mock.Arguments) {
-		arg := args.Get(2).(*beer.Beer)
-		arg.Id = 0
-	})
+	mockRepo.On("FindById", ctx, mock.AnythingOfType("int64")).Return(nil, common.ErrNotFound)
 
 	// Act
 	testQuery := NewGetBeerByIdHandler(mockRepo)
@@ -69,12 +69,32 @@ func Test_Handler_GetBeerById_Not_Found(t *testing.T) {
 	assert.Equal(t, errors.ErrorTypeNotFound, err.(errors.ApplicationError).ErrorType())
 }
 
+func Test_Handle_GetBeerById_Zero_Id(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	stored := beer.Beer{}
+	stored.Name = "test"
+	mockRepo.On("FindById", ctx, int64(0)).Return(stored, nil)
+
+	// Act
+	testQuery := NewGetBeerByIdHandler(mockRepo)
+	result, err := testQuery.Handle(ctx, GetBeerById{Id: 0})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "test", result.Name)
+}
+
 func Test_Handler_GetBeerById_Error(t *testing.T) {
 	// Arrange
-	mockRepo := new(mocks.MockRepository)
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
 	ctx := context.Background()
 
-	mockRepo.On("FindById", ctx, mock.AnythingOfType("int64"), mock.AnythingOfType("*beer.Beer")).Return(errorsN.New("An error has occurred"))
+	mockRepo.On("FindById", ctx, mock.AnythingOfType("int64")).Return(nil, errorsN.New("An error has occurred"))
 
 	// Act
 	testQuery := NewGetBeerByIdHandler(mockRepo)
@@ -86,3 +106,58 @@ func Test_Handler_GetBeerById_Error(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), "An error has occurred")
 }
+
+func Test_Handle_GetBeerById_Fields(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	options := common.ReadOptions{}
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	mockRepo.On("FindById", ctx, int64(1), mock.AnythingOfType("[]common.ReadOption")).Return(stored, nil).Run(func(args mock.Arguments) {
+		for _, opt := range args.Get(2).([]common.ReadOption) {
+			opt(&options)
+		}
+	})
+
+	// Act
+	testQuery := NewGetBeerByIdHandler(mockRepo)
+	_, err := testQuery.Handle(ctx, GetBeerById{Id: 1, Fields: []string{"id", "name", "price"}})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Equal(t, bson.D{{Key: "_id", Value: 1}, {Key: "name", Value: 1}, {Key: "price", Value: 1}, {Key: "version", Value: 1}}, options.Projection)
+}
+
+func Test_Handle_GetBeerById_Unknown_Field(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+
+	// Act
+	testQuery := NewGetBeerByIdHandler(mockRepo)
+	_, err := testQuery.Handle(context.Background(), GetBeerById{Id: 1, Fields: []string{"name", "createdBy"}})
+
+	// Assert
+	mockRepo.AssertNotCalled(t, "FindById")
+
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeBadRequest, err.(errors.ApplicationError).ErrorType())
+}
+
+func Test_SelectableFields_Match_BeerResponse(t *testing.T) {
+	names := []string{}
+	responseType := reflect.TypeOf(response.BeerResponse{})
+
+	for i := 0; i < responseType.NumField(); i++ {
+		names = append(names, strings.Split(responseType.Field(i).Tag.Get("json"), ",")[0])
+	}
+
+	for _, name := range names {
+		assert.Contains(t, selectableFields, name)
+	}
+
+	assert.Len(t, selectableFields, len(names))
+}
@@ -0,0 +1,170 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/juanmaabanto/go-ms-beers/internal/ports/response"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// maxFacetBuckets bounds the values counted for a field, the most frequent
+// ones are kept.
+const maxFacetBuckets = 50
+
+// maxPriceBuckets bounds the price buckets of all the currencies together, the
+// lowest ones are kept.
+const maxPriceBuckets = 500
+
+// defaultPriceInterval is the width of the price buckets when none is given,
+// and minPriceInterval the narrowest one accepted.
+var (
+	defaultPriceInterval = decimal.NewFromInt(10)
+	minPriceInterval     = decimal.New(1, 2)
+)
+
+// GetBeerFacets counts the beers matching the filter by country, brewery and
+// currency, and by price in buckets of PriceInterval for every currency.
+type GetBeerFacets struct {
+	BeerFilter
+	PriceInterval *decimal.Decimal
+}
+
+type GetBeerFacetsHandler struct {
+	repo beer.Repository
+}
+
+func NewGetBeerFacetsHandler(repo beer.Repository) GetBeerFacetsHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	return GetBeerFacetsHandler{repo}
+}
+
+type facetBucket struct {
+	Value string `bson:"_id"`
+	Count int64  `bson:"count"`
+}
+
+type priceBucket struct {
+	Key struct {
+		Currency string          `bson:"currency"`
+		From     decimal.Decimal `bson:"from"`
+	} `bson:"_id"`
+	Count int64 `bson:"count"`
+}
+
+type facets struct {
+	Total      []struct{ Count int64 } `bson:"total"`
+	Countries  []facetBucket           `bson:"countries"`
+	Breweries  []facetBucket           `bson:"breweries"`
+	Currencies []facetBucket           `bson:"currencies"`
+	Prices     []priceBucket           `bson:"prices"`
+}
+
+func (h GetBeerFacetsHandler) Handle(ctx context.Context, query GetBeerFacets) (response.FacetsResponse, error) {
+	result := response.FacetsResponse{
+		Countries:  []response.FacetBucketResponse{},
+		Breweries:  []response.FacetBucketResponse{},
+		Currencies: []response.FacetBucketResponse{},
+		Prices:     []response.PriceBucketResponse{},
+	}
+
+	if err := query.validate(); err != nil {
+		return result, err
+	}
+
+	interval := defaultPriceInterval
+
+	if query.PriceInterval != nil {
+		interval = *query.PriceInterval
+	}
+
+	if interval.Cmp(minPriceInterval) < 0 {
+		return result, errors.NewValidationError(map[string]string{"priceInterval": fmt.Sprintf("The price interval must be at least %s.", minPriceInterval)})
+	}
+
+	var documents []facets
+
+	err := h.repo.Aggregate(ctx, query.document(), facetStages(interval), &documents)
+
+	if err != nil || len(documents) == 0 {
+		return result, err
+	}
+
+	document := documents[0]
+
+	if len(document.Total) > 0 {
+		result.Total = document.Total[0].Count
+	}
+
+	result.Countries = bucketResponses(document.Countries)
+	result.Breweries = bucketResponses(document.Breweries)
+	result.Currencies = bucketResponses(document.Currencies)
+
+	for _, bucket := range document.Prices {
+		result.Prices = append(result.Prices, response.PriceBucketResponse{
+			Currency: bucket.Key.Currency,
+			From:     bucket.Key.From,
+			To:       bucket.Key.From.Add(interval),
+			Count:    bucket.Count,
+		})
+	}
+
+	return result, nil
+}
+
+// facetStages computes every facet in a single $facet stage, so all of them
+// see the same beers.
+func facetStages(interval decimal.Decimal) mongo.Pipeline {
+	return mongo.Pipeline{
+		bson.D{{Key: "$facet", Value: bson.D{
+			{Key: "total", Value: bson.A{bson.D{{Key: "$count", Value: "count"}}}},
+			{Key: "countries", Value: countStages("$country")},
+			{Key: "breweries", Value: countStages("$brewery")},
+			{Key: "currencies", Value: countStages("$currency")},
+			{Key: "prices", Value: bson.A{
+				bson.D{{Key: "$match", Value: bson.D{{Key: "price", Value: bson.D{{Key: "$ne", Value: nil}}}}}},
+				bson.D{{Key: "$group", Value: bson.D{
+					{Key: "_id", Value: bson.D{
+						{Key: "currency", Value: "$currency"},
+						{Key: "from", Value: bson.D{{Key: "$multiply", Value: bson.A{
+							bson.D{{Key: "$floor", Value: bson.D{{Key: "$divide", Value: bson.A{"$price", interval}}}}},
+							interval,
+						}}}},
+					}},
+					{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+				}}},
+				bson.D{{Key: "$sort", Value: bson.D{{Key: "_id.currency", Value: 1}, {Key: "_id.from", Value: 1}}}},
+				bson.D{{Key: "$limit", Value: maxPriceBuckets}},
+			}},
+		}}},
+	}
+}
+
+// countStages counts the beers by the value of field, most frequent first.
+func countStages(field string) bson.A {
+	return bson.A{
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: field},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+		bson.D{{Key: "$limit", Value: maxFacetBuckets}},
+	}
+}
+
+func bucketResponses(buckets []facetBucket) []response.FacetBucketResponse {
+	results := []response.FacetBucketResponse{}
+
+	for _, bucket := range buckets {
+		results = append(results, response.FacetBucketResponse{Value: bucket.Value, Count: bucket.Count})
+	}
+
+	return results
+}
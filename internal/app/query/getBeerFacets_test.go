@@ -0,0 +1,136 @@
+package query
+
+import (
+	"context"
+	errorsN "errors"
+	"testing"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/common/mocks"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func Test_NewGetBeerFacetsHandler(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("panic")
+		}
+	}()
+
+	NewGetBeerFacetsHandler(nil)
+}
+
+func Test_Handle_GetBeerFacets_Error(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	mockRepo.On("Aggregate", ctx, mock.AnythingOfType("primitive.D"), mock.AnythingOfType("mongo.Pipeline"), mock.AnythingOfType("*[]query.facets")).Return(errorsN.New("An error"))
+
+	// Act
+	testQuery := NewGetBeerFacetsHandler(mockRepo)
+	_, err := testQuery.Handle(ctx, GetBeerFacets{})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Error(t, err)
+	assert.Equal(t, "An error", err.Error())
+}
+
+func Test_Handle_GetBeerFacets_Ok(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	interval := decimal.NewFromInt(5)
+	filter := BeerFilter{Countries: []string{"PE"}}
+
+	mockRepo.On("Aggregate", ctx, filter.document(), facetStages(interval), mock.AnythingOfType("*[]query.facets")).Return(nil).Run(func(args mock.Arguments) {
+		document := facets{
+			Total:      []struct{ Count int64 }{{Count: 3}},
+			Countries:  []facetBucket{{Value: "PE", Count: 3}},
+			Breweries:  []facetBucket{{Value: "Backus", Count: 2}, {Value: "Ambev", Count: 1}},
+			Currencies: []facetBucket{{Value: "PEN", Count: 3}},
+			Prices:     []priceBucket{{Count: 2}},
+		}
+		document.Prices[0].Key.Currency = "PEN"
+		document.Prices[0].Key.From = decimal.NewFromInt(5)
+
+		arg := args.Get(3).(*[]facets)
+		*arg = append(*arg, document)
+	})
+
+	// Act
+	testQuery := NewGetBeerFacetsHandler(mockRepo)
+	result, err := testQuery.Handle(ctx, GetBeerFacets{BeerFilter: filter, PriceInterval: &interval})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), result.Total)
+	assert.Equal(t, "Backus", result.Breweries[0].Value)
+	assert.Equal(t, int64(2), result.Breweries[0].Count)
+	assert.Equal(t, "PEN", result.Prices[0].Currency)
+	assert.Equal(t, "5", result.Prices[0].From.String())
+	assert.Equal(t, "10", result.Prices[0].To.String())
+}
+
+func Test_Handle_GetBeerFacets_Empty(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	mockRepo.On("Aggregate", ctx, bson.D{}, mock.AnythingOfType("mongo.Pipeline"), mock.AnythingOfType("*[]query.facets")).Return(nil)
+
+	// Act
+	testQuery := NewGetBeerFacetsHandler(mockRepo)
+	result, err := testQuery.Handle(ctx, GetBeerFacets{})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), result.Total)
+	assert.NotNil(t, result.Countries)
+	assert.NotNil(t, result.Prices)
+}
+
+func Test_Handle_GetBeerFacets_Invalid(t *testing.T) {
+	zero := decimal.NewFromInt(0)
+	tiny := decimal.MustParse("0.0000001")
+
+	tests := []struct {
+		name  string
+		query GetBeerFacets
+		field string
+	}{
+		{name: "zero interval", query: GetBeerFacets{PriceInterval: &zero}, field: "priceInterval"},
+		{name: "interval below a cent", query: GetBeerFacets{PriceInterval: &tiny}, field: "priceInterval"},
+		{name: "unknown currency", query: GetBeerFacets{BeerFilter: BeerFilter{Currencies: []string{"XYZ"}}}, field: "currency"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+
+			testQuery := NewGetBeerFacetsHandler(mockRepo)
+			_, err := testQuery.Handle(context.Background(), tt.query)
+
+			mockRepo.AssertNotCalled(t, "Aggregate")
+
+			assert.IsType(t, errors.ApplicationError{}, err)
+			assert.Contains(t, err.(errors.ApplicationError).Errors(), tt.field)
+		})
+	}
+}
+
+func Test_FacetStages_Limit_Price_Buckets(t *testing.T) {
+	stages := facetStages(decimal.New(1, 2))
+	prices := stages[0][0].Value.(bson.D)[4].Value.(bson.A)
+
+	assert.Equal(t, bson.D{{Key: "$limit", Value: maxPriceBuckets}}, prices[len(prices)-1])
+}
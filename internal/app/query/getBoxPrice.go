@@ -2,118 +2,76 @@ package query
 
 import (
 	"context"
-	"encoding/json"
-	"io/ioutil"
-	"net/http"
-	"strconv"
+	errorsN "errors"
 
+	"github.com/juanmaabanto/go-ms-beers/common"
 	"github.com/juanmaabanto/go-ms-beers/common/errors"
 	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/pricing"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/tax"
 	"github.com/juanmaabanto/go-ms-beers/internal/ports/response"
 )
 
 type GetBoxPrice struct {
-	Id       int64
-	Currency string
-	Quantity int64
+	Id          int64
+	Currency    string
+	Quantity    int64
+	Destination string
 }
 
 type GetBoxPriceHandler struct {
-	repo beer.Repository
+	repo   beer.Repository
+	pricer boxPricer
 }
 
-func NewGetBoxPriceHandler(repo beer.Repository) GetBoxPriceHandler {
+func NewGetBoxPriceHandler(repo beer.Repository, rates currency.ExchangeRateProvider, rules pricing.Rules, taxes tax.Rules) GetBoxPriceHandler {
 	if repo == nil {
 		panic("nil repo")
 	}
 
-	return GetBoxPriceHandler{repo}
-}
-
-func (h GetBoxPriceHandler) Handle(ctx context.Context, query GetBoxPrice) (*response.PriceResponse, error) {
-	receiver := beer.Beer{}
-
-	err := h.repo.FindById(ctx, query.Id, &receiver)
-
-	if err != nil {
-		return nil, err
-	}
-
-	if receiver.Id == 0 {
-		return nil, errors.NewNotFoundError("beer")
-	}
-
-	endPrice := 0.00
-
-	if len(query.Currency) < 1 || query.Currency == receiver.Currency {
-		endPrice = receiver.Price
-	} else {
-		ratio, err := convertCurrent(receiver.Currency, query.Currency)
-
-		if err != nil {
-			return nil, err
-		}
-		endPrice = receiver.Price * ratio
+	if rates == nil {
+		panic("nil exchange rate provider")
 	}
 
-	response := response.PriceResponse{
-		PriceTotal: endPrice * float64(query.Quantity),
-	}
-
-	return &response, nil
-
+	return GetBoxPriceHandler{repo, boxPricer{rates, rules, taxes}}
 }
 
-func convertCurrent(source string, target string) (float64, error) {
-	client := &http.Client{}
-	uri := "http://api.currencylayer.com/live?access_key=b17cd86be31831425bf635f9e8be3f26&currencies=" + source + "," + target
-
-	req, err := http.NewRequest(http.MethodGet, uri, nil)
+func (h GetBoxPriceHandler) Handle(ctx context.Context, query GetBoxPrice) (*response.PriceResponse, error) {
+	query.Currency = currency.Normalize(query.Currency)
 
-	if err != nil {
-		return 0, err
+	if len(query.Currency) > 0 && !currency.IsKnown(query.Currency) {
+		return nil, unknownCurrencyError(query.Currency)
 	}
 
-	res, err := client.Do(req)
-	if err != nil {
-		return 0, err
+	if query.Quantity <= 0 {
+		return nil, quantityError("The quantity must be greater than zero.")
 	}
 
-	defer res.Body.Close()
+	destination, taxLines, err := h.pricer.taxLines(query.Destination)
 
-	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	c := make(map[string]json.RawMessage)
+	receiver, err := h.repo.FindById(ctx, query.Id)
 
-	err = json.Unmarshal(body, &c)
-
-	if err != nil {
-		return 0, nil
+	if errorsN.Is(err, common.ErrNotFound) {
+		return nil, errors.NewNotFoundError("beer")
 	}
 
-	results := make(map[string]json.RawMessage)
-
-	err = json.Unmarshal(c["quotes"], &results)
-
 	if err != nil {
-		return 0, nil
+		return nil, err
 	}
 
-	m1, err := strconv.ParseFloat(string(results["USD"+source]), 64)
+	breakdown, err := h.pricer.price(ctx, receiver, query.Currency, query.Quantity, taxLines, rateCache{})
 
 	if err != nil {
-		return 0, nil
+		return nil, err
 	}
 
-	m2, err := strconv.ParseFloat(string(results["USD"+target]), 64)
-
-	if err != nil {
-		return 0, nil
-	}
+	response := newPriceResponse(breakdown)
+	response.Destination = destination
 
-	return (1.00 / m1) * m2, nil
+	return &response, nil
 }
-
@@ -3,11 +3,18 @@ package query
 import (
 	"context"
 	errorsN "errors"
+	"fmt"
 	"testing"
 
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
 	"github.com/juanmaabanto/go-ms-beers/common/errors"
 	"github.com/juanmaabanto/go-ms-beers/common/mocks"
 	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/pricing"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/tax"
+	"github.com/juanmaabanto/go-ms-beers/internal/infrastructure"
 	"github.com/juanmaabanto/go-ms-beers/internal/ports/response"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -20,46 +27,42 @@ func Test_NewGetBoxPriceHandler(t *testing.T) {
 		}
 	}()
 
-	NewGetBoxPriceHandler(nil)
+	NewGetBoxPriceHandler(nil, infrastructure.NewInMemoryRateProvider(), pricing.DefaultRules(), tax.Rules{})
 }
 
 func Test_Handle_GetBoxPrice_Found(t *testing.T) {
 	// Arrange
-	mockRepo := new(mocks.MockRepository)
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
 	ctx := context.Background()
-	expected := response.PriceResponse{PriceTotal: 10}
+	expected := response.PriceResponse{PriceTotal: decimal.MustParse("60.00")}
 
-	mockRepo.On("FindById", ctx, int64(1), mock.AnythingOfType("*beer.Beer")).Return(nil).Run(func(args mock.Arguments) {
-		arg := args.Get(2).(*beer.Beer)
-		arg.Id = 1
-		arg.Name = "test"
-		arg.Price = 10
-	})
+	stored := beer.Beer{}
+	stored.Id = 1
+	stored.Name = "test"
+	stored.Price = decimal.NewFromInt(10)
+	mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
 
 	// Act
-	testQuery := NewGetBoxPriceHandler(mockRepo)
-	result, err := testQuery.Handle(ctx, GetBoxPrice{Id: 1, Quantity: 1})
+	testQuery := NewGetBoxPriceHandler(mockRepo, infrastructure.NewInMemoryRateProvider(), pricing.DefaultRules(), tax.Rules{})
+	result, err := testQuery.Handle(ctx, GetBoxPrice{Id: 1, Quantity: 6})
 
 	// Assert
 	mockRepo.AssertExpectations(t)
 
-	assert.Equal(t, expected.PriceTotal, result.PriceTotal)
+	assert.Equal(t, expected.PriceTotal.String(), result.PriceTotal.String())
 	assert.Nil(t, err)
 }
 
 func Test_Handler_GetBoxPrice_Not_Found(t *testing.T) {
 	// Arrange
-	mockRepo := new(mocks.MockRepository)
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
 	ctx := context.Background()
 
-	mockRepo.On("FindById", ctx, mock.AnythingOfType("int64"), mock.AnythingOfType("*beer.Beer")).Return(nil).Run(func(args mock.Arguments) {
-		arg := args.Get(2).(*beer.Beer)
-		arg.Id = 0
-	})
+	mockRepo.On("FindById", ctx, mock.AnythingOfType("int64")).Return(nil, common.ErrNotFound)
 
 	// Act
-	testQuery := NewGetBoxPriceHandler(mockRepo)
-	_, err := testQuery.Handle(ctx, GetBoxPrice{Id: 1})
+	testQuery := NewGetBoxPriceHandler(mockRepo, infrastructure.NewInMemoryRateProvider(), pricing.DefaultRules(), tax.Rules{})
+	_, err := testQuery.Handle(ctx, GetBoxPrice{Id: 1, Quantity: 6})
 
 	// Assert
 	mockRepo.AssertExpectations(t)
@@ -71,14 +74,14 @@ func Test_Handler_GetBoxPrice_Not_Found(t *testing.T) {
 
 func Test_Handler_GetBoxPrice_Error(t *testing.T) {
 	// Arrange
-	mockRepo := new(mocks.MockRepository)
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
 	ctx := context.Background()
 
-	mockRepo.On("FindById", ctx, mock.AnythingOfType("int64"), mock.AnythingOfType("*beer.Beer")).Return(errorsN.New("An error has occurred"))
+	mockRepo.On("FindById", ctx, mock.AnythingOfType("int64")).Return(nil, errorsN.New("An error has occurred"))
 
 	// Act
-	testQuery := NewGetBoxPriceHandler(mockRepo)
-	_, err := testQuery.Handle(ctx, GetBoxPrice{Id: 1})
+	testQuery := NewGetBoxPriceHandler(mockRepo, infrastructure.NewInMemoryRateProvider(), pricing.DefaultRules(), tax.Rules{})
+	_, err := testQuery.Handle(ctx, GetBoxPrice{Id: 1, Quantity: 6})
 
 	// Assert
 	mockRepo.AssertExpectations(t)
@@ -87,34 +90,292 @@ func Test_Handler_GetBoxPrice_Error(t *testing.T) {
 	assert.Equal(t, err.Error(), "An error has occurred")
 }
 
-func Test_convertCurrent(t *testing.T) {
-	type args struct {
-		source string
-		target string
+func Test_NewGetBoxPriceHandler_Nil_Rates(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("panic")
+		}
+	}()
+
+	NewGetBoxPriceHandler(new(mocks.MockRepository[beer.Beer, int64]), nil, pricing.DefaultRules(), tax.Rules{})
+}
+
+func Test_Handle_GetBoxPrice_Converted(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	rates := infrastructure.NewInMemoryRateProvider()
+	ctx := context.Background()
+
+	rates.SetRate("EUR", "PEN", 4)
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	stored.Price = decimal.NewFromInt(10)
+	stored.Currency = "EUR"
+	mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+
+	// Act
+	testQuery := NewGetBoxPriceHandler(mockRepo, rates, pricing.DefaultRules(), tax.Rules{})
+	result, err := testQuery.Handle(ctx, GetBoxPrice{Id: 1, Currency: "PEN", Quantity: 6})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "240.00", result.PriceTotal.String())
+	assert.Equal(t, 1, rates.Calls())
+}
+
+func Test_Handle_GetBoxPrice_Breakdown(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	rates := infrastructure.NewInMemoryRateProvider()
+	ctx := context.Background()
+
+	rates.SetRate("EUR", "PEN", 4.1)
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	stored.Price = decimal.MustParse("2.99")
+	stored.Currency = "EUR"
+	mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+
+	// Act
+	testQuery := NewGetBoxPriceHandler(mockRepo, rates, pricing.DefaultRules(), tax.Rules{})
+	result, err := testQuery.Handle(ctx, GetBoxPrice{Id: 1, Currency: "PEN", Quantity: 6})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "PEN", result.Currency)
+	assert.Equal(t, "EUR", result.UnitPrice.Currency)
+	assert.Equal(t, "2.99", result.UnitPrice.Amount.String())
+	assert.Equal(t, "4.1", result.ExchangeRate.Value.String())
+	assert.Equal(t, "memory", result.ExchangeRate.Provider)
+	assert.Equal(t, "12.26", result.ConvertedUnitPrice.Amount.String())
+	assert.Equal(t, int64(6), result.Quantity)
+	assert.Equal(t, "73.56", result.Subtotal.String())
+	assert.Empty(t, result.Discounts)
+	assert.Empty(t, result.Taxes)
+	assert.Equal(t, "73.56", result.Total.String())
+	assert.Equal(t, result.Total, result.PriceTotal)
+}
+
+func Test_Handle_GetBoxPrice_Rate_Error(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	rates := infrastructure.NewInMemoryRateProvider()
+	ctx := context.Background()
+
+	rates.Err = errorsN.New("An error has occurred")
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	stored.Price = decimal.NewFromInt(10)
+	stored.Currency = "EUR"
+	mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+
+	// Act
+	testQuery := NewGetBoxPriceHandler(mockRepo, rates, pricing.DefaultRules(), tax.Rules{})
+	_, err := testQuery.Handle(ctx, GetBoxPrice{Id: 1, Currency: "PEN", Quantity: 6})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Error(t, err)
+	assert.Equal(t, err.Error(), "An error has occurred")
+}
+
+func Test_Handle_GetBoxPrice_Unknown_Currency(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	rates := infrastructure.NewInMemoryRateProvider()
+	ctx := context.Background()
+
+	// Act
+	testQuery := NewGetBoxPriceHandler(mockRepo, rates, pricing.DefaultRules(), tax.Rules{})
+	_, err := testQuery.Handle(ctx, GetBoxPrice{Id: 1, Currency: "XYZ", Quantity: 6})
+
+	// Assert
+	mockRepo.AssertNotCalled(t, "FindById")
+
+	assert.Error(t, err)
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeValidation, err.(errors.ApplicationError).ErrorType())
+	assert.Equal(t, 0, rates.Calls())
+}
+
+func Test_Handle_GetBoxPrice_Rate_Error_Types(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want errors.ErrorType
+	}{
+		{name: "unknown currency", err: currency.ErrUnknownCurrency, want: errors.ErrorTypeValidation},
+		{name: "missing quote", err: currency.ErrMissingQuote, want: errors.ErrorTypeBadGateway},
+		{name: "malformed payload", err: currency.ErrMalformedPayload, want: errors.ErrorTypeBadGateway},
+		{name: "unavailable", err: fmt.Errorf("%w: timeout", currency.ErrUnavailable), want: errors.ErrorTypeServiceUnavailable},
 	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+			rates := infrastructure.NewInMemoryRateProvider()
+			ctx := context.Background()
+
+			rates.Err = tt.err
+
+			stored := beer.Beer{}
+			stored.Id = 1
+			stored.Price = decimal.NewFromInt(10)
+			stored.Currency = "EUR"
+			mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+
+			testQuery := NewGetBoxPriceHandler(mockRepo, rates, pricing.DefaultRules(), tax.Rules{})
+			result, err := testQuery.Handle(ctx, GetBoxPrice{Id: 1, Currency: "pen", Quantity: 6})
+
+			assert.Nil(t, result)
+			assert.IsType(t, errors.ApplicationError{}, err)
+			assert.Equal(t, tt.want, err.(errors.ApplicationError).ErrorType())
+		})
+	}
+}
+
+func Test_Handle_GetBoxPrice_Rounded_To_Currency(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	rates := infrastructure.NewInMemoryRateProvider()
+	ctx := context.Background()
+
+	rates.SetRate("EUR", "JPY", 130.456)
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	stored.Price = decimal.MustParse("12.00")
+	stored.Currency = "EUR"
+	mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+
+	// Act
+	testQuery := NewGetBoxPriceHandler(mockRepo, rates, pricing.DefaultRules(), tax.Rules{})
+	result, err := testQuery.Handle(ctx, GetBoxPrice{Id: 1, Currency: "JPY", Quantity: 6})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1565", result.ConvertedUnitPrice.Amount.String())
+	assert.Equal(t, "9390", result.PriceTotal.String())
+}
+
+func Test_Handle_GetBoxPrice_Invalid_Quantity(t *testing.T) {
 	tests := []struct {
-		name    string
-		args    args
-		want    float64
-		wantErr bool
+		name     string
+		quantity int64
 	}{
-		{
-			name:    "EUR to PEN",
-			args:    args{source: "EUR", target: "PEN"},
-			want:    10,
-			wantErr: false,
-		},
+		{name: "zero", quantity: 0},
+		{name: "negative", quantity: -6},
+		{name: "not a box size", quantity: 7},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := convertCurrent(tt.args.source, tt.args.target)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("convertCurrent() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if got <= 0 {
-				t.Errorf("convertCurrent() = %v, want %v", got, tt.want)
-			}
+			mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+			ctx := context.Background()
+
+			stored := beer.Beer{}
+			stored.Id = 1
+			stored.Price = decimal.NewFromInt(10)
+			mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+
+			testQuery := NewGetBoxPriceHandler(mockRepo, infrastructure.NewInMemoryRateProvider(), pricing.DefaultRules(), tax.Rules{})
+			_, err := testQuery.Handle(ctx, GetBoxPrice{Id: 1, Quantity: tt.quantity})
+
+			assert.IsType(t, errors.ApplicationError{}, err)
+			assert.Equal(t, errors.ErrorTypeValidation, err.(errors.ApplicationError).ErrorType())
+			assert.Contains(t, err.(errors.ApplicationError).Errors(), "quantity")
 		})
 	}
 }
+
+func Test_Handle_GetBoxPrice_Discount(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	rules := pricing.Rules{
+		{BoxSizes: []int64{6, 12, 24}},
+		{Scope: pricing.Scope{Brewery: "Backus"}, Tiers: []pricing.Tier{
+			{Name: "Backus 12 pack", MinQuantity: 12, Percentage: decimal.NewFromInt(10)},
+		}},
+	}
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	stored.Brewery = "Backus"
+	stored.Price = decimal.MustParse("3.50")
+	stored.Currency = "PEN"
+	mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+
+	// Act
+	testQuery := NewGetBoxPriceHandler(mockRepo, infrastructure.NewInMemoryRateProvider(), rules, tax.Rules{})
+	result, err := testQuery.Handle(ctx, GetBoxPrice{Id: 1, Quantity: 12})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "42.00", result.Subtotal.String())
+	assert.Equal(t, []response.AdjustmentResponse{{Name: "Backus 12 pack", Amount: decimal.MustParse("4.20")}}, result.Discounts)
+	assert.Equal(t, "37.80", result.PriceTotal.String())
+}
+
+func Test_Handle_GetBoxPrice_Taxes(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	rates := infrastructure.NewInMemoryRateProvider()
+	ctx := context.Background()
+	taxes := tax.Rules{"PE": {
+		{Name: "ISC", Kind: tax.KindExcise, PerUnit: decimal.MustParse("0.5"), Currency: "PEN", MinAbv: decimal.MustParse("0.5")},
+		{Name: "IGV", Kind: tax.KindVat, Percentage: decimal.NewFromInt(18)},
+	}}
+
+	rates.SetRate("PEN", "USD", 0.25)
+
+	stored := beer.Beer{}
+	stored.Id = 1
+	stored.Price = decimal.MustParse("10.00")
+	stored.Currency = "PEN"
+	stored.Abv = decimal.MustParse("4.8")
+	mockRepo.On("FindById", ctx, int64(1)).Return(stored, nil)
+
+	// Act
+	testQuery := NewGetBoxPriceHandler(mockRepo, rates, pricing.DefaultRules(), taxes)
+	result, err := testQuery.Handle(ctx, GetBoxPrice{Id: 1, Currency: "USD", Quantity: 6, Destination: "pe"})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "PE", result.Destination)
+	assert.Equal(t, "15.00", result.Subtotal.String())
+	assert.Equal(t, []response.AdjustmentResponse{
+		{Name: "ISC", Amount: decimal.MustParse("0.75")},
+		{Name: "IGV", Amount: decimal.MustParse("2.84")},
+	}, result.Taxes)
+	assert.Equal(t, "18.59", result.PriceTotal.String())
+}
+
+func Test_Handle_GetBoxPrice_Unknown_Destination(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	// Act
+	testQuery := NewGetBoxPriceHandler(mockRepo, infrastructure.NewInMemoryRateProvider(), pricing.DefaultRules(), tax.Rules{})
+	_, err := testQuery.Handle(ctx, GetBoxPrice{Id: 1, Quantity: 6, Destination: "CL"})
+
+	// Assert
+	mockRepo.AssertNotCalled(t, "FindById")
+
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeValidation, err.(errors.ApplicationError).ErrorType())
+	assert.Contains(t, err.(errors.ApplicationError).Errors(), "destination")
+}
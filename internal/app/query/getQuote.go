@@ -0,0 +1,188 @@
+package query
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/money"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/pricing"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/tax"
+	"github.com/juanmaabanto/go-ms-beers/internal/ports/response"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type GetQuote struct {
+	Currency    string      `json:"currency" validate:"required,len=3"`
+	Destination string      `json:"destination" validate:"omitempty,len=2"`
+	Lines       []QuoteLine `json:"lines" validate:"required,min=1,max=100,dive"`
+}
+
+type QuoteLine struct {
+	BeerId   int64 `json:"beerId" validate:"required"`
+	Quantity int64 `json:"quantity" validate:"required,gt=0"`
+}
+
+type GetQuoteHandler struct {
+	repo   beer.Repository
+	pricer boxPricer
+}
+
+func NewGetQuoteHandler(repo beer.Repository, rates currency.ExchangeRateProvider, rules pricing.Rules, taxes tax.Rules) GetQuoteHandler {
+	if repo == nil {
+		panic("nil repo")
+	}
+
+	if rates == nil {
+		panic("nil exchange rate provider")
+	}
+
+	return GetQuoteHandler{repo, boxPricer{rates, rules, taxes}}
+}
+
+// Handle prices every line of a cart in the quote currency. The beers are
+// loaded at once and each currency is converted once; a line whose beer is
+// missing or whose quantity is not sold reports its error and is left out of
+// the total. A rate that cannot be read fails the whole quote, so that a
+// partial total is never returned as the price of the cart.
+func (h GetQuoteHandler) Handle(ctx context.Context, query GetQuote) (*response.QuoteResponse, error) {
+	query.Currency = currency.Normalize(query.Currency)
+
+	if !currency.IsKnown(query.Currency) {
+		return nil, unknownCurrencyError(query.Currency)
+	}
+
+	if len(query.Lines) == 0 {
+		return nil, errors.NewValidationError(map[string]string{"lines": "The quote needs at least one line."})
+	}
+
+	for _, line := range query.Lines {
+		if line.Quantity <= 0 {
+			return nil, quantityError("The quantity must be greater than zero.")
+		}
+	}
+
+	destination, taxLines, err := h.pricer.taxLines(query.Destination)
+
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := h.findBeers(ctx, query.Lines)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rates := rateCache{}
+
+	result := response.QuoteResponse{
+		Currency:    query.Currency,
+		Destination: destination,
+		Lines:       []response.QuoteLineResponse{},
+	}
+	total := money.New(decimal.Decimal{}, query.Currency)
+
+	for _, line := range query.Lines {
+		quoteLine := response.QuoteLineResponse{BeerId: line.BeerId, Quantity: line.Quantity}
+		item, ok := items[line.BeerId]
+
+		if !ok {
+			quoteLine.Error = "The beer was not found."
+			result.Lines = append(result.Lines, quoteLine)
+			continue
+		}
+
+		breakdown, err := h.pricer.price(ctx, item, query.Currency, line.Quantity, taxLines, rates)
+
+		if appErr, ok := err.(errors.ApplicationError); ok && isQuantityError(appErr) {
+			quoteLine.Error = lineError(appErr)
+			result.Lines = append(result.Lines, quoteLine)
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		price := newPriceResponse(breakdown)
+		price.Destination = destination
+		quoteLine.Total = &price.Total
+		quoteLine.Price = &price
+
+		if total, err = total.Add(breakdown.Total()); err != nil {
+			return nil, err
+		}
+
+		result.Lines = append(result.Lines, quoteLine)
+	}
+
+	result.Total = total.Round().Amount
+
+	return &result, nil
+}
+
+// isQuantityError reports whether a line failed because its quantity is not
+// sold.
+func isQuantityError(err errors.ApplicationError) bool {
+	_, ok := err.Errors()["quantity"]
+
+	return err.ErrorType() == errors.ErrorTypeValidation && ok
+}
+
+// lineError tells in one sentence per field why a line could not be priced.
+func lineError(err errors.ApplicationError) string {
+	fields := make([]string, 0, len(err.Errors()))
+
+	for field := range err.Errors() {
+		fields = append(fields, field)
+	}
+
+	if len(fields) == 0 {
+		return err.Error()
+	}
+
+	sort.Strings(fields)
+
+	messages := make([]string, len(fields))
+
+	for i, field := range fields {
+		messages[i] = err.Errors()[field]
+	}
+
+	return strings.Join(messages, " ")
+}
+
+// findBeers loads the beers of every line in a single query.
+func (h GetQuoteHandler) findBeers(ctx context.Context, lines []QuoteLine) (map[int64]beer.Beer, error) {
+	ids := []int64{}
+	seen := map[int64]bool{}
+
+	for _, line := range lines {
+		if !seen[line.BeerId] {
+			seen[line.BeerId] = true
+			ids = append(ids, line.BeerId)
+		}
+	}
+
+	filter := bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}}
+	sort := bson.D{{Key: "_id", Value: 1}}
+
+	items, err := h.repo.Paginated(ctx, filter, sort, int64(len(ids)), 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[int64]beer.Beer{}
+
+	for _, item := range items {
+		result[item.Id] = item
+	}
+
+	return result, nil
+}
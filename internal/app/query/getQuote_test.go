@@ -0,0 +1,211 @@
+package query
+
+import (
+	"context"
+	errorsN "errors"
+	"testing"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/common/mocks"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/pricing"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/tax"
+	"github.com/juanmaabanto/go-ms-beers/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_NewGetQuoteHandler(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("panic")
+		}
+	}()
+
+	NewGetQuoteHandler(nil, infrastructure.NewInMemoryRateProvider(), pricing.DefaultRules(), tax.Rules{})
+}
+
+func Test_Handle_GetQuote_Completed(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	rates := infrastructure.NewInMemoryRateProvider()
+	ctx := context.Background()
+
+	rates.SetRate("EUR", "PEN", 4)
+
+	mockRepo.On("Paginated", ctx, mock.AnythingOfType("primitive.D"), mock.AnythingOfType("primitive.D"), int64(4), int64(0)).Return([]beer.Beer{
+		{Id: 1, Price: decimal.MustParse("2.50"), Currency: "EUR"},
+		{Id: 2, Price: decimal.MustParse("1.10"), Currency: "EUR"},
+		{Id: 3, Price: decimal.MustParse("5.00"), Currency: "PEN"},
+	}, nil)
+
+	// Act
+	testQuery := NewGetQuoteHandler(mockRepo, rates, pricing.DefaultRules(), tax.Rules{})
+	result, err := testQuery.Handle(ctx, GetQuote{Currency: "PEN", Lines: []QuoteLine{
+		{BeerId: 1, Quantity: 6},
+		{BeerId: 2, Quantity: 12},
+		{BeerId: 3, Quantity: 6},
+		{BeerId: 4, Quantity: 6},
+		{BeerId: 1, Quantity: 7},
+	}})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, rates.Calls())
+	assert.Len(t, result.Lines, 5)
+	assert.Equal(t, "60.00", result.Lines[0].Total.String())
+	assert.Equal(t, "52.80", result.Lines[1].Total.String())
+	assert.Equal(t, "30.00", result.Lines[2].Total.String())
+	assert.Equal(t, "The beer was not found.", result.Lines[3].Error)
+	assert.Nil(t, result.Lines[3].Price)
+	assert.Nil(t, result.Lines[3].Total)
+	assert.Equal(t, "The quantity must be one of 6, 12, 24.", result.Lines[4].Error)
+	assert.Equal(t, "142.80", result.Total.String())
+}
+
+func Test_Handle_GetQuote_Rate_Error(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	rates := infrastructure.NewInMemoryRateProvider()
+	ctx := context.Background()
+
+	rates.SetRate("EUR", "PEN", 4)
+
+	mockRepo.On("Paginated", ctx, mock.AnythingOfType("primitive.D"), mock.AnythingOfType("primitive.D"), int64(2), int64(0)).Return([]beer.Beer{
+		{Id: 1, Price: decimal.MustParse("2.50"), Currency: "EUR"},
+		{Id: 2, Price: decimal.MustParse("1.10"), Currency: "USD"},
+	}, nil)
+
+	// Act
+	testQuery := NewGetQuoteHandler(mockRepo, rates, pricing.DefaultRules(), tax.Rules{})
+	result, err := testQuery.Handle(ctx, GetQuote{Currency: "PEN", Lines: []QuoteLine{
+		{BeerId: 1, Quantity: 6},
+		{BeerId: 2, Quantity: 6},
+	}})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Nil(t, result)
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeBadGateway, err.(errors.ApplicationError).ErrorType())
+}
+
+func Test_Handle_GetQuote_Rate_Unavailable(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	rates := infrastructure.NewInMemoryRateProvider()
+	ctx := context.Background()
+
+	rates.Err = currency.ErrUnavailable
+
+	mockRepo.On("Paginated", ctx, mock.AnythingOfType("primitive.D"), mock.AnythingOfType("primitive.D"), int64(1), int64(0)).Return([]beer.Beer{
+		{Id: 1, Price: decimal.MustParse("2.50"), Currency: "EUR"},
+	}, nil)
+
+	// Act
+	testQuery := NewGetQuoteHandler(mockRepo, rates, pricing.DefaultRules(), tax.Rules{})
+	result, err := testQuery.Handle(ctx, GetQuote{Currency: "PEN", Lines: []QuoteLine{{BeerId: 1, Quantity: 6}}})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Nil(t, result)
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeServiceUnavailable, err.(errors.ApplicationError).ErrorType())
+}
+
+func Test_Handle_GetQuote_Excise_Rate_Once(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	rates := infrastructure.NewInMemoryRateProvider()
+	ctx := context.Background()
+	taxes := tax.Rules{"PE": {
+		{Name: "ISC", Kind: tax.KindExcise, PerUnit: decimal.MustParse("0.5"), Currency: "USD"},
+	}}
+
+	rates.SetRate("EUR", "PEN", 4)
+	rates.SetRate("USD", "PEN", 3)
+
+	mockRepo.On("Paginated", ctx, mock.AnythingOfType("primitive.D"), mock.AnythingOfType("primitive.D"), int64(2), int64(0)).Return([]beer.Beer{
+		{Id: 1, Price: decimal.MustParse("2.50"), Currency: "EUR"},
+		{Id: 2, Price: decimal.MustParse("5.00"), Currency: "PEN"},
+	}, nil)
+
+	// Act
+	testQuery := NewGetQuoteHandler(mockRepo, rates, pricing.DefaultRules(), taxes)
+	result, err := testQuery.Handle(ctx, GetQuote{Currency: "PEN", Destination: "PE", Lines: []QuoteLine{
+		{BeerId: 1, Quantity: 6},
+		{BeerId: 2, Quantity: 6},
+		{BeerId: 1, Quantity: 12},
+	}})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, rates.Calls())
+	assert.Equal(t, "69.00", result.Lines[0].Total.String())
+	assert.Equal(t, "39.00", result.Lines[1].Total.String())
+}
+
+func Test_Handle_GetQuote_Unknown_Currency(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	// Act
+	testQuery := NewGetQuoteHandler(mockRepo, infrastructure.NewInMemoryRateProvider(), pricing.DefaultRules(), tax.Rules{})
+	_, err := testQuery.Handle(ctx, GetQuote{Currency: "XYZ", Lines: []QuoteLine{{BeerId: 1, Quantity: 6}}})
+
+	// Assert
+	mockRepo.AssertNotCalled(t, "Paginated")
+
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeValidation, err.(errors.ApplicationError).ErrorType())
+}
+
+func Test_Handle_GetQuote_Empty(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	// Act
+	testQuery := NewGetQuoteHandler(mockRepo, infrastructure.NewInMemoryRateProvider(), pricing.DefaultRules(), tax.Rules{})
+	_, err := testQuery.Handle(ctx, GetQuote{Currency: "PEN"})
+
+	// Assert
+	assert.IsType(t, errors.ApplicationError{}, err)
+	assert.Equal(t, errors.ErrorTypeValidation, err.(errors.ApplicationError).ErrorType())
+}
+
+func Test_Handle_GetQuote_Error(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	mockRepo.On("Paginated", ctx, mock.AnythingOfType("primitive.D"), mock.AnythingOfType("primitive.D"), int64(1), int64(0)).Return(nil, errorsN.New("An error"))
+
+	// Act
+	testQuery := NewGetQuoteHandler(mockRepo, infrastructure.NewInMemoryRateProvider(), pricing.DefaultRules(), tax.Rules{})
+	_, err := testQuery.Handle(ctx, GetQuote{Currency: "PEN", Lines: []QuoteLine{{BeerId: 1, Quantity: 6}}})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Error(t, err)
+	assert.Equal(t, "An error", err.Error())
+}
+
+func Test_LineError(t *testing.T) {
+	assert.Equal(t, "The quantity must be one of 6, 12.", lineError(errors.NewValidationError(map[string]string{"quantity": "The quantity must be one of 6, 12."})))
+	assert.Equal(t, "The currency is not supported. The quantity must be one of 6, 12.", lineError(errors.NewValidationError(map[string]string{
+		"quantity": "The quantity must be one of 6, 12.",
+		"currency": "The currency is not supported.",
+	})))
+	assert.Equal(t, "The exchange rate service is unavailable.", lineError(errors.NewServiceUnavailableError("The exchange rate service is unavailable.")))
+}
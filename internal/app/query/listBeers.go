@@ -2,67 +2,216 @@ package query
 
 import (
 	"context"
+	"strings"
 
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/cursor"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
 	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
 	"github.com/juanmaabanto/go-ms-beers/internal/ports/response"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 )
 
+// ListBeers reads a page by offset with Start, or next to the position of
+// Cursor when one is given. When Fields is set only those fields of
+// response.BeerResponse are read.
 type ListBeers struct {
-	Name     string
+	BeerFilter
+	Sort     string
+	Fields   []string
+	Cursor   string
 	Start    int64
 	PageSize int64
 }
 
+// BeerPage is a page of beers with the cursors of the pages around it. A
+// cursor is empty when there is no page on that side.
+type BeerPage struct {
+	Total      int64
+	Items      []response.BeerResponse
+	NextCursor string
+	PrevCursor string
+}
+
 type ListBeersHandler struct {
-	repo beer.Repository
+	repo    beer.Repository
+	cursors cursor.Codec
 }
 
-func NewListBeersHandler(repo beer.Repository) ListBeersHandler {
+func NewListBeersHandler(repo beer.Repository, cursors cursor.Codec) ListBeersHandler {
 	if repo == nil {
 		panic("nil repo")
 	}
 
-	return ListBeersHandler{repo}
+	return ListBeersHandler{repo, cursors}
 }
 
-func (h ListBeersHandler) Handle(ctx context.Context, query ListBeers) (int64, []response.BeerResponse, error) {
-	var items []beer.Beer
-	results := []response.BeerResponse{}
-
-	filter := bson.D{
-		{"$or",
-			bson.A{
-				bson.D{{"name", primitive.Regex{
-					Pattern: query.Name,
-					Options: "i",
-				}}},
-			}},
+func (h ListBeersHandler) Handle(ctx context.Context, query ListBeers) (BeerPage, error) {
+	var raw []bson.Raw
+	page := BeerPage{Items: []response.BeerResponse{}}
+
+	if query.Start < 0 || query.PageSize <= 0 {
+		return page, errors.NewBadRequestError("The start cannot be negative and the page size must be greater than zero.")
+	}
+
+	if err := query.validate(); err != nil {
+		return page, err
+	}
+
+	sort, err := sortDocument(query.Sort)
+
+	if err != nil {
+		return page, err
+	}
+
+	byRelevance := query.rankedByRelevance() && strings.TrimSpace(query.Sort) == ""
+
+	if byRelevance {
+		sort = relevanceSort()
+	}
+
+	// The sort fields are read too, the cursors are made of them. The text
+	// score is not a field of the beer.
+	sortFields := []string{}
+
+	for _, field := range sort {
+		if _, ok := field.Value.(int); ok {
+			sortFields = append(sortFields, field.Key)
+		}
 	}
 
+	opts, err := fieldsOptions(query.Fields, sortFields...)
+
+	if err != nil {
+		return page, err
+	}
+
+	opts = append(opts, common.WithRaw(&raw))
+
+	filter := query.document()
+
 	total, err := h.repo.Count(ctx, filter)
 
 	if err != nil {
-		return 0, results, err
+		return page, err
+	}
+
+	page.Total = total
+
+	if query.Cursor != "" {
+		if byRelevance {
+			return page, errors.NewBadRequestError("Cursors cannot be used when sorting by relevance.")
+		}
+
+		position, err := h.cursors.Decode(query.Cursor)
+
+		if err != nil {
+			return page, errors.NewBadRequestError("The cursor is not valid.")
+		}
+
+		if position.Sort != sortSignature(sort) {
+			return page, errors.NewBadRequestError("The cursor was issued for another sort.")
+		}
+
+		keyset := common.Keyset{Values: position.Values, Backward: position.Backward}
+
+		items, err := h.repo.PaginatedByKeyset(ctx, filter, sort, keyset, query.PageSize+1, opts...)
+
+		if err != nil {
+			return page, err
+		}
+
+		more := int64(len(items)) > query.PageSize
+
+		if more && position.Backward {
+			items, raw = items[1:], raw[1:]
+		} else if more {
+			items, raw = items[:query.PageSize], raw[:query.PageSize]
+		}
+
+		hasNext, hasPrev := more, true
+
+		if position.Backward {
+			hasNext, hasPrev = true, more
+		}
+
+		err = h.fillPage(&page, items, raw, sort, hasNext, hasPrev)
+
+		return page, err
 	}
 
-	err = h.repo.Paginated(ctx, filter, bson.D{}, query.PageSize, query.Start, &items)
+	items, err := h.repo.Paginated(ctx, filter, sort, query.PageSize, query.Start, opts...)
 
 	if err != nil {
-		return 0, results, err
+		return page, err
 	}
 
+	hasNext := query.Start+int64(len(items)) < total
+	hasPrev := query.Start > 0
+
+	err = h.fillPage(&page, items, raw, sort, hasNext && !byRelevance, hasPrev && !byRelevance)
+
+	return page, err
+}
+
+// fillPage adds the beers and signs the cursors of the pages around them. The
+// cursors hold the stored values of the raw documents, so a beer without a
+// field is found again where MongoDB sorts it.
+func (h ListBeersHandler) fillPage(page *BeerPage, items []beer.Beer, documents []bson.Raw, sort bson.D, hasNext bool, hasPrev bool) error {
 	for _, element := range items {
-		results = append(results, response.BeerResponse{
+		page.Items = append(page.Items, response.BeerResponse{
 			Id:       element.Id,
 			Name:     element.Name,
 			Brewery:  element.Brewery,
 			Country:  element.Country,
 			Price:    element.Price,
 			Currency: element.Currency,
+			Abv:      element.Abv,
+			Version:  element.Version,
+		})
+	}
+
+	if len(documents) == 0 {
+		return nil
+	}
+
+	var err error
+
+	if hasNext {
+		page.NextCursor, err = h.cursors.Encode(cursor.Cursor{
+			Sort:   sortSignature(sort),
+			Values: sortValues(documents[len(documents)-1], sort),
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if hasPrev {
+		page.PrevCursor, err = h.cursors.Encode(cursor.Cursor{
+			Sort:     sortSignature(sort),
+			Values:   sortValues(documents[0], sort),
+			Backward: true,
 		})
 	}
 
-	return total, results, err
+	return err
+}
+
+func sortValues(document bson.Raw, sort bson.D) []interface{} {
+	values := []interface{}{}
+
+	for _, field := range sort {
+		value := document.Lookup(field.Key)
+
+		if value.Type == 0 || value.Type == bsontype.Null || value.Type == bsontype.Undefined {
+			values = append(values, nil)
+		} else {
+			values = append(values, value)
+		}
+	}
+
+	return values
 }
@@ -2,15 +2,24 @@ package query
 
 import (
 	"context"
-	"errors"
+	errorsN "errors"
+	"strings"
 	"testing"
 
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/cursor"
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
 	"github.com/juanmaabanto/go-ms-beers/common/mocks"
 	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var testCursors = cursor.NewCodec([]byte("secret"))
+
 func Test_NewListBeersHandler(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
@@ -18,19 +27,19 @@ func Test_NewListBeersHandler(t *testing.T) {
 		}
 	}()
 
-	NewListBeersHandler(nil)
+	NewListBeersHandler(nil, testCursors)
 }
 
 func Test_Handle_ListBeers_Count_Err(t *testing.T) {
 	// Arrange
-	mockRepo := new(mocks.MockRepository)
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
 	ctx := context.Background()
 
-	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.D")).Return(int64(0), errors.New("An error"))
+	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.D")).Return(int64(0), errorsN.New("An error"))
 
 	// Act
-	testQuery := NewListBeersHandler(mockRepo)
-	_, _, err := testQuery.Handle(ctx, ListBeers{Name: "", Start: 0, PageSize: 50})
+	testQuery := NewListBeersHandler(mockRepo, testCursors)
+	_, err := testQuery.Handle(ctx, ListBeers{Start: 0, PageSize: 50})
 
 	// Assert
 	mockRepo.AssertExpectations(t)
@@ -41,15 +50,15 @@ func Test_Handle_ListBeers_Count_Err(t *testing.T) {
 
 func Test_Handle_ListBeers_Paginated_Error(t *testing.T) {
 	// Arrange
-	mockRepo := new(mocks.MockRepository)
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
 	ctx := context.Background()
 
 	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.D")).Return(int64(1), nil)
-	mockRepo.On("Paginated", ctx, mock.AnythingOfType("primitive.D"), mock.AnythingOfType("primitive.D"), int64(50), int64(0), mock.AnythingOfType("*[]beer.Beer")).Return(errors.New("An error"))
+	mockRepo.On("Paginated", ctx, mock.AnythingOfType("primitive.D"), mock.AnythingOfType("primitive.D"), int64(50), int64(0), mock.AnythingOfType("[]common.ReadOption")).Return(nil, errorsN.New("An error"))
 
 	// Act
-	testQuery := NewListBeersHandler(mockRepo)
-	_, _, err := testQuery.Handle(ctx, ListBeers{Name: "", Start: 0, PageSize: 50})
+	testQuery := NewListBeersHandler(mockRepo, testCursors)
+	_, err := testQuery.Handle(ctx, ListBeers{Start: 0, PageSize: 50})
 
 	// Assert
 	mockRepo.AssertExpectations(t)
@@ -60,26 +69,337 @@ func Test_Handle_ListBeers_Paginated_Error(t *testing.T) {
 
 func Test_Handle_ListBeers_Ok(t *testing.T) {
 	// Arrange
-	mockRepo := new(mocks.MockRepository)
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
 	ctx := context.Background()
 
 	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.D")).Return(int64(1), nil)
-	mockRepo.On("Paginated", ctx, mock.AnythingOfType("primitive.D"), mock.AnythingOfType("primitive.D"), int64(50), int64(0), mock.AnythingOfType("*[]beer.Beer")).Return(nil).Run(func(args mock.Arguments) {
-		arg := args.Get(5).(*[]beer.Beer)
+	mockRepo.On("Paginated", ctx, mock.AnythingOfType("primitive.D"), mock.AnythingOfType("primitive.D"), int64(50), int64(0), mock.AnythingOfType("[]common.ReadOption")).Return([]beer.Beer{{Name: "test"}}, nil)
+
+	// Act
+	testQuery := NewListBeersHandler(mockRepo, testCursors)
+	page, _ := testQuery.Handle(ctx, ListBeers{Start: 0, PageSize: 50})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.Equal(t, int64(1), page.Total)
+	assert.Equal(t, "test", page.Items[0].Name)
+	assert.Empty(t, page.NextCursor)
+	assert.Empty(t, page.PrevCursor)
+}
+
+func Test_Handle_ListBeers_Offset_Returns_Cursors(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+
+	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.D")).Return(int64(5), nil)
+	mockRepo.On("Paginated", ctx, mock.AnythingOfType("primitive.D"), mock.AnythingOfType("primitive.D"), int64(2), int64(2), mock.AnythingOfType("[]common.ReadOption")).Return([]beer.Beer{{Id: 3}, {Id: 4}}, nil)
+
+	// Act
+	testQuery := NewListBeersHandler(mockRepo, testCursors)
+	page, err := testQuery.Handle(ctx, ListBeers{Start: 2, PageSize: 2})
+	next, _ := testCursors.Decode(page.NextCursor)
+	prev, _ := testCursors.Decode(page.PrevCursor)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{int64(4)}, next.Values)
+	assert.False(t, next.Backward)
+	assert.Equal(t, []interface{}{int64(3)}, prev.Values)
+	assert.True(t, prev.Backward)
+}
+
+func Test_Handle_ListBeers_Cursor(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	sort := bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}
+	token, _ := testCursors.Encode(cursor.Cursor{Sort: "name:1,_id:1", Values: []interface{}{"Cusqueña", int64(3)}})
+	keyset := common.Keyset{Values: []interface{}{"Cusqueña", int64(3)}}
+
+	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.D")).Return(int64(5), nil)
+	mockRepo.On("PaginatedByKeyset", ctx, mock.AnythingOfType("primitive.D"), sort, keyset, int64(3), mock.AnythingOfType("[]common.ReadOption")).Return([]beer.Beer{{Id: 4, Name: "Pilsen"}, {Id: 1, Name: "Pilsen"}, {Id: 2, Name: "Quilmes"}}, nil)
+
+	// Act
+	testQuery := NewListBeersHandler(mockRepo, testCursors)
+	page, err := testQuery.Handle(ctx, ListBeers{Sort: "name", Cursor: token, PageSize: 2})
+	next, _ := testCursors.Decode(page.NextCursor)
+	prev, _ := testCursors.Decode(page.PrevCursor)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
 
-		*arg = append(*arg, beer.Beer{
-			Name: "test",
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, []interface{}{"Pilsen", int64(1)}, next.Values)
+	assert.Equal(t, []interface{}{"Pilsen", int64(4)}, prev.Values)
+}
+
+func Test_Handle_ListBeers_Backward_Cursor_On_First_Page(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	token, _ := testCursors.Encode(cursor.Cursor{Sort: "_id:1", Values: []interface{}{int64(3)}, Backward: true})
+
+	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.D")).Return(int64(5), nil)
+	mockRepo.On("PaginatedByKeyset", ctx, mock.AnythingOfType("primitive.D"), mock.AnythingOfType("primitive.D"), mock.AnythingOfType("common.Keyset"), int64(3), mock.AnythingOfType("[]common.ReadOption")).Return([]beer.Beer{{Id: 1}, {Id: 2}}, nil)
+
+	// Act
+	testQuery := NewListBeersHandler(mockRepo, testCursors)
+	page, err := testQuery.Handle(ctx, ListBeers{Cursor: token, PageSize: 2})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+	assert.NotEmpty(t, page.NextCursor)
+	assert.Empty(t, page.PrevCursor)
+}
+
+func Test_Handle_ListBeers_Invalid_Cursor(t *testing.T) {
+	other, _ := testCursors.Encode(cursor.Cursor{Sort: "price:-1,_id:1", Values: []interface{}{"10", int64(3)}})
+	forged, _ := cursor.NewCodec([]byte("other")).Encode(cursor.Cursor{Sort: "_id:1", Values: []interface{}{int64(3)}})
+
+	tests := []struct {
+		name  string
+		query ListBeers
+	}{
+		{name: "forged", query: ListBeers{Cursor: forged, PageSize: 50}},
+		{name: "another sort", query: ListBeers{Cursor: other, PageSize: 50}},
+		{name: "relevance", query: ListBeers{BeerFilter: BeerFilter{Name: "lager", Search: SearchText}, Cursor: other, PageSize: 50}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+			mockRepo.On("Count", mock.Anything, mock.AnythingOfType("primitive.D")).Return(int64(0), nil)
+
+			testQuery := NewListBeersHandler(mockRepo, testCursors)
+			_, err := testQuery.Handle(context.Background(), tt.query)
+
+			mockRepo.AssertNotCalled(t, "PaginatedByKeyset")
+
+			assert.IsType(t, errors.ApplicationError{}, err)
+			assert.Equal(t, errors.ErrorTypeBadRequest, err.(errors.ApplicationError).ErrorType())
 		})
+	}
+}
+
+func Test_Handle_ListBeers_Same_Filter_For_Count_And_Page(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	minPrice := decimal.MustParse("5")
 
+	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.D")).Return(int64(0), nil)
+	mockRepo.On("Paginated", ctx, mock.AnythingOfType("primitive.D"), mock.AnythingOfType("primitive.D"), int64(50), int64(0), mock.AnythingOfType("[]common.ReadOption")).Return(nil, nil)
+
+	// Act
+	testQuery := NewListBeersHandler(mockRepo, testCursors)
+	_, err := testQuery.Handle(ctx, ListBeers{
+		BeerFilter: BeerFilter{Countries: []string{"PE", "CL"}, MinPrice: &minPrice},
+		PageSize:   50,
+	})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Equal(t, mockRepo.Calls[0].Arguments.Get(1), mockRepo.Calls[1].Arguments.Get(1))
+}
+
+func Test_Handle_ListBeers_Invalid_Filter(t *testing.T) {
+	minPrice := decimal.MustParse("10")
+	maxPrice := decimal.MustParse("5")
+	negative := decimal.MustParse("-1")
+
+	tests := []struct {
+		name   string
+		filter BeerFilter
+		field  string
+	}{
+		{name: "unknown currency", filter: BeerFilter{Currencies: []string{"PEN", "XYZ"}}, field: "currency"},
+		{name: "min over max", filter: BeerFilter{MinPrice: &minPrice, MaxPrice: &maxPrice}, field: "minPrice"},
+		{name: "negative max", filter: BeerFilter{MaxPrice: &negative}, field: "maxPrice"},
+		{name: "unknown search", filter: BeerFilter{Name: "lager", Search: "regex"}, field: "search"},
+		{name: "text without name", filter: BeerFilter{Search: SearchText}, field: "name"},
+		{name: "long name", filter: BeerFilter{Name: strings.Repeat("a", 101)}, field: "name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+
+			testQuery := NewListBeersHandler(mockRepo, testCursors)
+			_, err := testQuery.Handle(context.Background(), ListBeers{BeerFilter: tt.filter, PageSize: 50})
+
+			mockRepo.AssertNotCalled(t, "Count")
+
+			assert.IsType(t, errors.ApplicationError{}, err)
+			assert.Contains(t, err.(errors.ApplicationError).Errors(), tt.field)
+		})
+	}
+}
+
+func Test_Handle_ListBeers_Invalid_Page(t *testing.T) {
+	for _, list := range []ListBeers{{Start: -1, PageSize: 50}, {PageSize: 0}, {PageSize: -5}} {
+		mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+
+		testQuery := NewListBeersHandler(mockRepo, testCursors)
+		_, err := testQuery.Handle(context.Background(), list)
+
+		mockRepo.AssertNotCalled(t, "Count")
+
+		assert.IsType(t, errors.ApplicationError{}, err)
+		assert.Equal(t, errors.ErrorTypeBadRequest, err.(errors.ApplicationError).ErrorType())
+	}
+}
+
+func Test_Handle_ListBeers_Fields_Read_Sort_Fields(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	options := common.ReadOptions{}
+
+	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.D")).Return(int64(0), nil)
+	mockRepo.On("Paginated", ctx, mock.AnythingOfType("primitive.D"), mock.AnythingOfType("primitive.D"), int64(50), int64(0), mock.AnythingOfType("[]common.ReadOption")).Return(nil, nil).Run(func(args mock.Arguments) {
+		for _, opt := range args.Get(5).([]common.ReadOption) {
+			opt(&options)
+		}
 	})
 
 	// Act
-	testQuery := NewListBeersHandler(mockRepo)
-	total, results, _ := testQuery.Handle(ctx, ListBeers{Name: "", Start: 0, PageSize: 50})
+	testQuery := NewListBeersHandler(mockRepo, testCursors)
+	_, err := testQuery.Handle(ctx, ListBeers{Sort: "-abv", Fields: []string{"name", "price"}, PageSize: 50})
 
 	// Assert
 	mockRepo.AssertExpectations(t)
 
-	assert.Equal(t, int64(1), total)
-	assert.Equal(t, "test", results[0].Name)
+	assert.NoError(t, err)
+	assert.Equal(t, bson.D{{Key: "name", Value: 1}, {Key: "price", Value: 1}, {Key: "abv", Value: 1}, {Key: "_id", Value: 1}}, options.Projection)
+}
+
+func Test_BeerFilter_Document(t *testing.T) {
+	minPrice := decimal.MustParse("5")
+	maxPrice := decimal.MustParse("20.50")
+
+	filter := BeerFilter{
+		Name:       "lager",
+		Breweries:  []string{"Backus"},
+		Countries:  []string{"PE", "C.L"},
+		Currencies: []string{"pen"},
+		MinPrice:   &minPrice,
+		MaxPrice:   &maxPrice,
+	}
+
+	expected := bson.D{{Key: "$and", Value: bson.A{
+		bson.D{{Key: "name", Value: primitive.Regex{Pattern: "lager", Options: "i"}}},
+		bson.D{{Key: "brewery", Value: bson.D{{Key: "$in", Value: bson.A{primitive.Regex{Pattern: "^Backus$", Options: "i"}}}}}},
+		bson.D{{Key: "country", Value: bson.D{{Key: "$in", Value: bson.A{
+			primitive.Regex{Pattern: "^PE$", Options: "i"},
+			primitive.Regex{Pattern: `^C\.L$`, Options: "i"},
+		}}}}},
+		bson.D{{Key: "currency", Value: bson.D{{Key: "$in", Value: bson.A{"PEN"}}}}},
+		bson.D{{Key: "price", Value: bson.D{{Key: "$gte", Value: minPrice}, {Key: "$lte", Value: maxPrice}}}},
+	}}}
+
+	assert.Equal(t, expected, filter.document())
+	assert.Equal(t, bson.D{}, BeerFilter{}.document())
+}
+
+func Test_BeerFilter_Name_Condition(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   BeerFilter
+		expected bson.D
+	}{
+		{
+			name:     "special characters",
+			filter:   BeerFilter{Name: "Lager (6+) .*"},
+			expected: bson.D{{Key: "name", Value: primitive.Regex{Pattern: `Lager \(6\+\) \.\*`, Options: "i"}}},
+		},
+		{
+			name:     "prefix",
+			filter:   BeerFilter{Name: "cus[", Search: SearchPrefix},
+			expected: bson.D{{Key: "name", Value: primitive.Regex{Pattern: `^cus\[`, Options: "i"}}},
+		},
+		{
+			name:     "text",
+			filter:   BeerFilter{Name: "golden ale", Search: SearchText},
+			expected: bson.D{{Key: "$text", Value: bson.D{{Key: "$search", Value: "golden ale"}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, bson.D{{Key: "$and", Value: bson.A{tt.expected}}}, tt.filter.document())
+		})
+	}
+}
+
+func Test_Handle_ListBeers_Text_Search_Sorts_By_Relevance(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	expected := bson.D{{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}}, {Key: "_id", Value: 1}}
+
+	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.D")).Return(int64(0), nil)
+	mockRepo.On("Paginated", ctx, mock.AnythingOfType("primitive.D"), expected, int64(50), int64(0), mock.AnythingOfType("[]common.ReadOption")).Return(nil, nil)
+
+	// Act
+	testQuery := NewListBeersHandler(mockRepo, testCursors)
+	_, err := testQuery.Handle(ctx, ListBeers{BeerFilter: BeerFilter{Name: "lager", Search: SearchText}, PageSize: 50})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+}
+
+func Test_Handle_ListBeers_Sort(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockRepository[beer.Beer, int64])
+	ctx := context.Background()
+	expected := bson.D{{Key: "price", Value: -1}, {Key: "name", Value: 1}, {Key: "_id", Value: 1}}
+
+	mockRepo.On("Count", ctx, mock.AnythingOfType("primitive.D")).Return(int64(0), nil)
+	mockRepo.On("Paginated", ctx, mock.AnythingOfType("primitive.D"), expected, int64(50), int64(0), mock.AnythingOfType("[]common.ReadOption")).Return(nil, nil)
+
+	// Act
+	testQuery := NewListBeersHandler(mockRepo, testCursors)
+	_, err := testQuery.Handle(ctx, ListBeers{Sort: "-price, name", PageSize: 50})
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+}
+
+func Test_SortDocument(t *testing.T) {
+	tests := []struct {
+		sort     string
+		expected bson.D
+	}{
+		{sort: "", expected: bson.D{{Key: "_id", Value: 1}}},
+		{sort: "-id", expected: bson.D{{Key: "_id", Value: -1}}},
+		{sort: "country,-id,name", expected: bson.D{{Key: "country", Value: 1}, {Key: "_id", Value: -1}, {Key: "name", Value: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			result, err := sortDocument(tt.sort)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_SortDocument_Invalid(t *testing.T) {
+	for _, sort := range []string{"createdAt", "-", "price,-price", "name;drop"} {
+		_, err := sortDocument(sort)
+
+		assert.IsType(t, errors.ApplicationError{}, err, sort)
+		assert.Equal(t, errors.ErrorTypeBadRequest, err.(errors.ApplicationError).ErrorType(), sort)
+	}
 }
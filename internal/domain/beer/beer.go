@@ -1,17 +1,27 @@
 package beer
 
-import "github.com/juanmaabanto/go-ms-beers/common"
+import (
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/money"
+)
 
 type Beer struct {
-	Id              int64   `bson:"_id"`
-	Name            string  `bson:"name"`
-	Brewery         string  `bson:"brewery"`
-	Country         string  `bson:"country"`
-	Price           float64 `bson:"price"`
-	Currency        string  `bson:"currency"`
+	Id              int64           `bson:"_id"`
+	Name            string          `bson:"name"`
+	Brewery         string          `bson:"brewery"`
+	Country         string          `bson:"country"`
+	Price           decimal.Decimal `bson:"price"`
+	Currency        string          `bson:"currency"`
+	Abv             decimal.Decimal `bson:"abv"`
 	common.Document `bson:"inline"`
 }
 
 func (_ Beer) GetCollectionName() string {
 	return "beer"
 }
+
+// UnitPrice returns the price of a single beer.
+func (b Beer) UnitPrice() money.Money {
+	return money.New(b.Price, b.Currency)
+}
@@ -5,5 +5,5 @@ import (
 )
 
 type Repository interface {
-	common.IBaseRepository
+	common.IRepository[Beer, int64]
 }
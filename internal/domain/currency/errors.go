@@ -0,0 +1,12 @@
+package currency
+
+import "errors"
+
+// Providers wrap these errors so callers can tell why a rate is missing with
+// errors.Is.
+var (
+	ErrUnknownCurrency  = errors.New("unknown currency")
+	ErrMissingQuote     = errors.New("missing quote")
+	ErrUnavailable      = errors.New("exchange rate service unavailable")
+	ErrMalformedPayload = errors.New("malformed exchange rate payload")
+)
@@ -0,0 +1,7 @@
+package currency
+
+import "context"
+
+type ExchangeRateProvider interface {
+	GetRate(ctx context.Context, source string, target string) (Rate, error)
+}
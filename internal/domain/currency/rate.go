@@ -0,0 +1,20 @@
+package currency
+
+import (
+	"time"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+)
+
+// RateScale is the number of decimals kept when a rate is derived from two
+// quotes against a third currency.
+const RateScale = 12
+
+// Rate is the value of one unit of Source expressed in Target.
+type Rate struct {
+	Source    string
+	Target    string
+	Value     decimal.Decimal
+	Timestamp time.Time
+	Provider  string
+}
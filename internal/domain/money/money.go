@@ -0,0 +1,60 @@
+package money
+
+import (
+	"fmt"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+)
+
+// defaultMinorUnits is used to round amounts whose currency is not in the ISO
+// 4217 table, such as prices stored before currencies were validated.
+const defaultMinorUnits = 2
+
+// Money is an exact amount of a currency. Operations keep every decimal, only
+// Round applies the rounding rule of the currency.
+type Money struct {
+	Amount   decimal.Decimal
+	Currency string
+}
+
+func New(amount decimal.Decimal, code string) Money {
+	return Money{Amount: amount, Currency: code}
+}
+
+func (m Money) Multiply(factor decimal.Decimal) Money {
+	return Money{Amount: m.Amount.Mul(factor), Currency: m.Currency}
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if other.Currency != m.Currency {
+		return Money{}, fmt.Errorf("cannot add %s to %s", other.Currency, m.Currency)
+	}
+
+	return Money{Amount: m.Amount.Add(other.Amount), Currency: m.Currency}, nil
+}
+
+// Convert expresses m in the target currency of rate.
+func (m Money) Convert(rate currency.Rate) (Money, error) {
+	if rate.Source != m.Currency {
+		return Money{}, fmt.Errorf("cannot convert %s with a rate from %s", m.Currency, rate.Source)
+	}
+
+	return Money{Amount: m.Amount.Mul(rate.Value), Currency: rate.Target}, nil
+}
+
+// Round rounds half away from zero to the minor units of the currency, 2
+// decimals for PEN and none for JPY.
+func (m Money) Round() Money {
+	units, ok := currency.MinorUnits(m.Currency)
+
+	if !ok {
+		units = defaultMinorUnits
+	}
+
+	return Money{Amount: m.Amount.Round(int32(units)), Currency: m.Currency}
+}
+
+func (m Money) String() string {
+	return m.Amount.String() + " " + m.Currency
+}
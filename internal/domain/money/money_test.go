@@ -0,0 +1,50 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Round(t *testing.T) {
+	tests := []struct {
+		currency string
+		amount   string
+		want     string
+	}{
+		{currency: "PEN", amount: "71.99999999", want: "72.00"},
+		{currency: "JPY", amount: "1565.472", want: "1565"},
+		{currency: "KWD", amount: "1.23456", want: "1.235"},
+		{currency: "", amount: "1.005", want: "1.01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.currency, func(t *testing.T) {
+			m := New(decimal.MustParse(tt.amount), tt.currency).Round()
+
+			assert.Equal(t, tt.want, m.Amount.String())
+		})
+	}
+}
+
+func Test_Convert(t *testing.T) {
+	m := New(decimal.MustParse("12.00"), "EUR")
+
+	converted, err := m.Convert(currency.Rate{Source: "EUR", Target: "PEN", Value: decimal.MustParse("4.1")})
+	_, mismatch := m.Convert(currency.Rate{Source: "USD", Target: "PEN", Value: decimal.MustParse("4.1")})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "49.20", converted.Round().Amount.String())
+	assert.Equal(t, "PEN", converted.Currency)
+	assert.Error(t, mismatch)
+}
+
+func Test_Add(t *testing.T) {
+	total, err := New(decimal.MustParse("0.1"), "PEN").Add(New(decimal.MustParse("0.2"), "PEN"))
+	_, mismatch := New(decimal.NewFromInt(1), "PEN").Add(New(decimal.NewFromInt(1), "USD"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0.3", total.Amount.String())
+	assert.Error(t, mismatch)
+}
@@ -0,0 +1,102 @@
+package pricing
+
+import (
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/money"
+)
+
+// Adjustment is a named amount taken from or added to the subtotal of a box.
+type Adjustment struct {
+	Name   string
+	Amount money.Money
+}
+
+// Breakdown explains how the price of a box was built. Every amount is in the
+// currency the customer pays in and rounded to its minor units, so the lines
+// always add up to the total.
+type Breakdown struct {
+	UnitPrice          money.Money
+	Rate               *currency.Rate
+	ConvertedUnitPrice money.Money
+	Quantity           int64
+	Subtotal           money.Money
+	Discounts          []Adjustment
+	Taxes              []Adjustment
+}
+
+// NewBreakdown prices quantity beers of unitPrice. The unit price is
+// converted with rate when it is not nil.
+func NewBreakdown(unitPrice money.Money, rate *currency.Rate, quantity int64) (Breakdown, error) {
+	converted := unitPrice
+
+	if rate != nil {
+		var err error
+
+		if converted, err = unitPrice.Convert(*rate); err != nil {
+			return Breakdown{}, err
+		}
+	}
+
+	converted = converted.Round()
+
+	return Breakdown{
+		UnitPrice:          unitPrice,
+		Rate:               rate,
+		ConvertedUnitPrice: converted,
+		Quantity:           quantity,
+		Subtotal:           converted.Multiply(decimal.NewFromInt(quantity)).Round(),
+		Discounts:          []Adjustment{},
+		Taxes:              []Adjustment{},
+	}, nil
+}
+
+// ApplyDiscount adds the discount of tier. A fixed amount is converted with
+// the rate of the breakdown, and no discount exceeds the subtotal.
+func (b *Breakdown) ApplyDiscount(tier Tier) error {
+	discount := b.Subtotal.Multiply(tier.Percentage.Mul(decimal.New(1, 2)))
+
+	if tier.Percentage.IsZero() {
+		discount = money.New(tier.Amount, b.UnitPrice.Currency)
+
+		if b.Rate != nil {
+			var err error
+
+			if discount, err = discount.Convert(*b.Rate); err != nil {
+				return err
+			}
+		}
+	}
+
+	discount = discount.Round()
+
+	if discount.Amount.Cmp(b.Subtotal.Amount) > 0 {
+		discount = b.Subtotal
+	}
+
+	b.Discounts = append(b.Discounts, Adjustment{Name: tier.Name, Amount: discount})
+
+	return nil
+}
+
+// Net is the subtotal minus the discounts, the base of the taxes.
+func (b Breakdown) Net() money.Money {
+	net := b.Subtotal.Amount
+
+	for _, discount := range b.Discounts {
+		net = net.Sub(discount.Amount.Amount)
+	}
+
+	return money.New(net, b.Subtotal.Currency).Round()
+}
+
+// Total is the subtotal minus the discounts plus the taxes.
+func (b Breakdown) Total() money.Money {
+	total := b.Net().Amount
+
+	for _, tax := range b.Taxes {
+		total = total.Add(tax.Amount.Amount)
+	}
+
+	return money.New(total, b.Subtotal.Currency).Round()
+}
@@ -0,0 +1,42 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Breakdown_Percentage_Discount(t *testing.T) {
+	breakdown, _ := NewBreakdown(money.New(decimal.MustParse("3.33"), "PEN"), nil, 12)
+
+	err := breakdown.ApplyDiscount(Tier{Name: "12 pack", Percentage: decimal.NewFromInt(5)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "39.96", breakdown.Subtotal.Amount.String())
+	assert.Equal(t, "2.00", breakdown.Discounts[0].Amount.Amount.String())
+	assert.Equal(t, "37.96", breakdown.Total().Amount.String())
+}
+
+func Test_Breakdown_Fixed_Discount_Converted(t *testing.T) {
+	rate := currency.Rate{Source: "EUR", Target: "PEN", Value: decimal.NewFromInt(4)}
+	breakdown, _ := NewBreakdown(money.New(decimal.MustParse("2.50"), "EUR"), &rate, 6)
+
+	err := breakdown.ApplyDiscount(Tier{Name: "fixed", Amount: decimal.MustParse("1.5")})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "60.00", breakdown.Subtotal.Amount.String())
+	assert.Equal(t, "6.00", breakdown.Discounts[0].Amount.Amount.String())
+	assert.Equal(t, "PEN", breakdown.Discounts[0].Amount.Currency)
+	assert.Equal(t, "54.00", breakdown.Total().Amount.String())
+}
+
+func Test_Breakdown_Discount_Capped_At_Subtotal(t *testing.T) {
+	breakdown, _ := NewBreakdown(money.New(decimal.NewFromInt(1), "PEN"), nil, 6)
+
+	breakdown.ApplyDiscount(Tier{Name: "fixed", Amount: decimal.NewFromInt(100)})
+
+	assert.Equal(t, "0.00", breakdown.Total().Amount.String())
+}
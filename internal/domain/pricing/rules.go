@@ -0,0 +1,139 @@
+package pricing
+
+import (
+	"strings"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+)
+
+// DefaultBoxSizes are the packs sold when no rule sets the box sizes.
+var DefaultBoxSizes = []int64{6, 12, 24}
+
+// Product is what the pricing rules know about a beer.
+type Product struct {
+	BeerId  int64
+	Brewery string
+	Country string
+}
+
+// Scope selects the beers a rule applies to. Every field that is set must
+// match, an empty scope matches every beer.
+type Scope struct {
+	BeerId  int64
+	Brewery string
+	Country string
+}
+
+// Tier is the discount of the boxes holding at least MinQuantity beers. It
+// is either a Percentage of the subtotal or a fixed Amount per box in the
+// currency of the beer.
+type Tier struct {
+	Name        string
+	MinQuantity int64
+	Percentage  decimal.Decimal
+	Amount      decimal.Decimal
+}
+
+type Rule struct {
+	Scope
+	BoxSizes []int64
+	Tiers    []Tier
+}
+
+// Rules holds every pricing rule. For each product the most specific matching
+// rule wins: a beer rule over a brewery rule, over a country rule, over a
+// global one. Box sizes and tiers are resolved separately, so a brewery rule
+// may only set tiers and keep the global box sizes.
+type Rules []Rule
+
+func DefaultRules() Rules {
+	return Rules{{BoxSizes: DefaultBoxSizes}}
+}
+
+// BoxSizes returns the quantities a product is sold in.
+func (r Rules) BoxSizes(product Product) []int64 {
+	rule, ok := r.match(product, func(rule Rule) bool { return len(rule.BoxSizes) > 0 })
+
+	if !ok {
+		return DefaultBoxSizes
+	}
+
+	return rule.BoxSizes
+}
+
+// Allows reports whether a box of quantity beers of the product is sold.
+func (r Rules) Allows(product Product, quantity int64) bool {
+	for _, size := range r.BoxSizes(product) {
+		if size == quantity {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Discount returns the tier with the highest MinQuantity reached by quantity.
+func (r Rules) Discount(product Product, quantity int64) (Tier, bool) {
+	rule, ok := r.match(product, func(rule Rule) bool { return len(rule.Tiers) > 0 })
+
+	if !ok {
+		return Tier{}, false
+	}
+
+	best, found := Tier{}, false
+
+	for _, tier := range rule.Tiers {
+		if tier.MinQuantity <= quantity && (!found || tier.MinQuantity > best.MinQuantity) {
+			best, found = tier, true
+		}
+	}
+
+	return best, found
+}
+
+func (r Rules) match(product Product, defines func(Rule) bool) (Rule, bool) {
+	best, bestWeight := Rule{}, -1
+
+	for _, rule := range r {
+		if !defines(rule) || !rule.Scope.matches(product) {
+			continue
+		}
+
+		if weight := rule.Scope.weight(); weight > bestWeight {
+			best, bestWeight = rule, weight
+		}
+	}
+
+	return best, bestWeight >= 0
+}
+
+func (s Scope) matches(product Product) bool {
+	if s.BeerId != 0 && s.BeerId != product.BeerId {
+		return false
+	}
+
+	if s.Brewery != "" && !strings.EqualFold(s.Brewery, product.Brewery) {
+		return false
+	}
+
+	return s.Country == "" || strings.EqualFold(s.Country, product.Country)
+}
+
+// weight ranks scopes so that a beer beats a brewery, which beats a country.
+func (s Scope) weight() int {
+	weight := 0
+
+	if s.BeerId != 0 {
+		weight += 4
+	}
+
+	if s.Brewery != "" {
+		weight += 2
+	}
+
+	if s.Country != "" {
+		weight++
+	}
+
+	return weight
+}
@@ -0,0 +1,48 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRules() Rules {
+	return Rules{
+		{BoxSizes: []int64{6, 12, 24}, Tiers: []Tier{
+			{Name: "12 pack", MinQuantity: 12, Percentage: decimal.NewFromInt(5)},
+			{Name: "24 pack", MinQuantity: 24, Percentage: decimal.NewFromInt(10)},
+		}},
+		{Scope: Scope{Country: "Peru"}, BoxSizes: []int64{6, 12}},
+		{Scope: Scope{Brewery: "Backus"}, Tiers: []Tier{
+			{Name: "Backus", MinQuantity: 6, Amount: decimal.NewFromInt(2)},
+		}},
+		{Scope: Scope{BeerId: 3}, BoxSizes: []int64{4}},
+	}
+}
+
+func Test_Rules_BoxSizes(t *testing.T) {
+	rules := testRules()
+
+	assert.Equal(t, []int64{6, 12, 24}, rules.BoxSizes(Product{BeerId: 1, Country: "Chile"}))
+	assert.Equal(t, []int64{6, 12}, rules.BoxSizes(Product{BeerId: 1, Country: "peru"}))
+	assert.Equal(t, []int64{4}, rules.BoxSizes(Product{BeerId: 3, Country: "Peru"}))
+	assert.Equal(t, DefaultBoxSizes, Rules{}.BoxSizes(Product{BeerId: 1}))
+	assert.True(t, rules.Allows(Product{BeerId: 1}, 24))
+	assert.False(t, rules.Allows(Product{BeerId: 1, Country: "Peru"}, 24))
+}
+
+func Test_Rules_Discount(t *testing.T) {
+	rules := testRules()
+
+	none, noneFound := rules.Discount(Product{BeerId: 1}, 6)
+	pack, packFound := rules.Discount(Product{BeerId: 1}, 24)
+	brewery, breweryFound := rules.Discount(Product{BeerId: 1, Brewery: "Backus", Country: "Peru"}, 12)
+
+	assert.False(t, noneFound)
+	assert.Equal(t, Tier{}, none)
+	assert.True(t, packFound)
+	assert.Equal(t, "24 pack", pack.Name)
+	assert.True(t, breweryFound)
+	assert.Equal(t, "Backus", brewery.Name)
+}
@@ -0,0 +1,102 @@
+package tax
+
+import (
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/money"
+)
+
+type Kind string
+
+const (
+	// KindExcise is a duty computed on the net price of the box or per beer.
+	KindExcise Kind = "excise"
+	// KindVat is a value added tax computed on the net price plus the excise
+	// duties.
+	KindVat Kind = "vat"
+)
+
+// Line is one tax of a destination. Percentage taxes apply to their base,
+// excise duties may instead charge PerUnit for every beer, in Currency. A
+// line only applies to the beers whose ABV is at least MinAbv and, when
+// MaxAbv is set, below MaxAbv.
+type Line struct {
+	Name       string
+	Kind       Kind
+	Percentage decimal.Decimal
+	PerUnit    decimal.Decimal
+	Currency   string
+	MinAbv     decimal.Decimal
+	MaxAbv     decimal.Decimal
+}
+
+// Charge is the amount of one tax line on a box.
+type Charge struct {
+	Name   string
+	Amount money.Money
+}
+
+// Converter expresses an amount in the target currency.
+type Converter func(amount money.Money, target string) (money.Money, error)
+
+// Rules holds the tax lines of every destination, by ISO 3166 country code.
+type Rules map[string][]Line
+
+// Lines returns the tax lines of a destination, and false when it has none
+// configured.
+func (r Rules) Lines(destination string) ([]Line, bool) {
+	lines, ok := r[destination]
+
+	return lines, ok
+}
+
+// Compute returns the charges of a box of quantity beers with the given ABV
+// and net price. Excise duties are computed first, so the VAT also applies to
+// them.
+func Compute(lines []Line, net money.Money, quantity int64, abv decimal.Decimal, convert Converter) ([]Charge, error) {
+	charges := []Charge{}
+	vatBase := net
+
+	for _, line := range lines {
+		if line.Kind != KindExcise || !line.applies(abv) {
+			continue
+		}
+
+		amount := net.Multiply(percent(line.Percentage))
+
+		if !line.PerUnit.IsZero() {
+			var err error
+
+			amount, err = convert(money.New(line.PerUnit.Mul(decimal.NewFromInt(quantity)), line.Currency), net.Currency)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		amount = amount.Round()
+		vatBase, _ = vatBase.Add(amount)
+		charges = append(charges, Charge{Name: line.Name, Amount: amount})
+	}
+
+	for _, line := range lines {
+		if line.Kind != KindVat || !line.applies(abv) {
+			continue
+		}
+
+		charges = append(charges, Charge{Name: line.Name, Amount: vatBase.Multiply(percent(line.Percentage)).Round()})
+	}
+
+	return charges, nil
+}
+
+func (l Line) applies(abv decimal.Decimal) bool {
+	if abv.Cmp(l.MinAbv) < 0 {
+		return false
+	}
+
+	return l.MaxAbv.IsZero() || abv.Cmp(l.MaxAbv) < 0
+}
+
+func percent(value decimal.Decimal) decimal.Decimal {
+	return value.Mul(decimal.New(1, 2))
+}
@@ -0,0 +1,58 @@
+package tax
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func sameCurrency(amount money.Money, target string) (money.Money, error) {
+	if amount.Currency != target {
+		return money.Money{}, errors.New("no rate")
+	}
+
+	return amount, nil
+}
+
+func Test_Compute(t *testing.T) {
+	lines := []Line{
+		{Name: "IGV", Kind: KindVat, Percentage: decimal.NewFromInt(18)},
+		{Name: "ISC", Kind: KindExcise, PerUnit: decimal.MustParse("1.50"), Currency: "PEN", MinAbv: decimal.MustParse("0.5")},
+		{Name: "Strong beer", Kind: KindExcise, Percentage: decimal.NewFromInt(10), MinAbv: decimal.NewFromInt(8)},
+	}
+	net := money.New(decimal.MustParse("60.00"), "PEN")
+
+	charges, err := Compute(lines, net, 6, decimal.MustParse("4.8"), sameCurrency)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Charge{
+		{Name: "ISC", Amount: money.New(decimal.MustParse("9.00"), "PEN")},
+		{Name: "IGV", Amount: money.New(decimal.MustParse("12.42"), "PEN")},
+	}, charges)
+}
+
+func Test_Compute_Alcohol_Free(t *testing.T) {
+	lines := []Line{
+		{Name: "ISC", Kind: KindExcise, PerUnit: decimal.MustParse("1.50"), Currency: "PEN", MinAbv: decimal.MustParse("0.5")},
+		{Name: "Light", Kind: KindExcise, Percentage: decimal.NewFromInt(5), MaxAbv: decimal.MustParse("0.5")},
+	}
+	net := money.New(decimal.MustParse("60.00"), "PEN")
+
+	charges, err := Compute(lines, net, 6, decimal.Decimal{}, sameCurrency)
+
+	assert.NoError(t, err)
+	assert.Len(t, charges, 1)
+	assert.Equal(t, "Light", charges[0].Name)
+	assert.Equal(t, "3.00", charges[0].Amount.Amount.String())
+}
+
+func Test_Compute_Conversion_Error(t *testing.T) {
+	lines := []Line{{Name: "ISC", Kind: KindExcise, PerUnit: decimal.NewFromInt(1), Currency: "PEN"}}
+
+	_, err := Compute(lines, money.New(decimal.NewFromInt(10), "USD"), 6, decimal.NewFromInt(5), sameCurrency)
+
+	assert.Error(t, err)
+}
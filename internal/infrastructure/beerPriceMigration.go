@@ -0,0 +1,56 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/juanmaabanto/go-ms-beers/common/database"
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// legacyPriceTypes are the BSON types prices were stored with before they
+// became Decimal128.
+var legacyPriceTypes = bson.A{"double", "int", "long"}
+
+// MigrateBeerPrices rewrites the prices stored as doubles or integers as
+// Decimal128 and returns how many beers changed. Versions are left alone
+// because the price itself does not change.
+func MigrateBeerPrices(ctx context.Context, connection database.MongoConnection) (int64, error) {
+	collection := connection.Database.Collection(beer.Beer{}.GetCollectionName())
+	filter := bson.M{"price": bson.M{"$type": legacyPriceTypes}}
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"price": 1}))
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer cursor.Close(ctx)
+
+	migrated := int64(0)
+
+	for cursor.Next(ctx) {
+		item := struct {
+			Id    int64           `bson:"_id"`
+			Price decimal.Decimal `bson:"price"`
+		}{}
+
+		if err := cursor.Decode(&item); err != nil {
+			return migrated, err
+		}
+
+		result, err := collection.UpdateOne(ctx,
+			bson.M{"_id": item.Id, "price": bson.M{"$type": legacyPriceTypes}},
+			bson.M{"$set": bson.M{"price": item.Price}})
+
+		if err != nil {
+			return migrated, err
+		}
+
+		migrated += result.ModifiedCount
+	}
+
+	return migrated, cursor.Err()
+}
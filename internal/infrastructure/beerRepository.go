@@ -1,19 +1,36 @@
 package infrastructure
 
 import (
+	"context"
+
 	"github.com/juanmaabanto/go-ms-beers/common"
 	"github.com/juanmaabanto/go-ms-beers/common/database"
 	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type BeerRepository struct {
-	common.BaseRepository
+	common.Repository[beer.Beer, int64]
 }
 
-func NewBeerRepository(connection database.MongoConnection, document beer.Beer) BeerRepository {
+func NewBeerRepository(connection database.MongoConnection) BeerRepository {
 	repository := BeerRepository{
-		BaseRepository: common.NewBaseRepository(connection, document),
+		Repository: common.NewRepository[beer.Beer, int64](connection),
 	}
 
 	return repository
 }
+
+// EnsureIndexes creates the text index used to search beers by relevance. A
+// match in the name weighs more than one in the brewery.
+func (repo BeerRepository) EnsureIndexes(ctx context.Context) error {
+	return repo.CreateIndexes(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "name", Value: "text"}, {Key: "brewery", Value: "text"}},
+		Options: options.Index().
+			SetName("beers_text").
+			SetWeights(bson.D{{Key: "name", Value: 10}, {Key: "brewery", Value: 2}}).
+			SetDefaultLanguage("none"),
+	})
+}
@@ -0,0 +1,136 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+	"golang.org/x/sync/singleflight"
+)
+
+type RateCacheConfig struct {
+	// TTL is how long a rate is served without asking the upstream provider.
+	TTL time.Duration
+	// ServeStale allows an expired rate to be served while it is refreshed in
+	// the background or while the upstream provider is failing.
+	ServeStale bool
+	// MaxStale is how long after its TTL a rate may still be served.
+	MaxStale time.Duration
+	// Timeout bounds every call to the upstream provider.
+	Timeout time.Duration
+	// FailureThreshold is the number of consecutive upstream failures that
+	// opens the circuit breaker, zero disables it.
+	FailureThreshold int
+	// Cooldown is how long the circuit breaker stays open.
+	Cooldown time.Duration
+}
+
+// CachedRateProvider keeps the rates of another provider in memory. Only one
+// refresh per currency pair is in flight at any time, and a circuit breaker
+// stops calling the upstream provider while it keeps failing.
+type CachedRateProvider struct {
+	provider currency.ExchangeRateProvider
+	config   RateCacheConfig
+	breaker  *circuitBreaker
+	group    singleflight.Group
+	now      func() time.Time
+
+	mutex   sync.RWMutex
+	entries map[string]cachedRate
+}
+
+type cachedRate struct {
+	rate      currency.Rate
+	fetchedAt time.Time
+}
+
+func NewCachedRateProvider(provider currency.ExchangeRateProvider, config RateCacheConfig) *CachedRateProvider {
+	if provider == nil {
+		panic("nil exchange rate provider")
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	return &CachedRateProvider{
+		provider: provider,
+		config:   config,
+		breaker:  newCircuitBreaker(config.FailureThreshold, config.Cooldown),
+		now:      time.Now,
+		entries:  map[string]cachedRate{},
+	}
+}
+
+func (p *CachedRateProvider) GetRate(ctx context.Context, source string, target string) (currency.Rate, error) {
+	key := source + target
+
+	p.mutex.RLock()
+	entry, found := p.entries[key]
+	p.mutex.RUnlock()
+
+	age := p.now().Sub(entry.fetchedAt)
+
+	if found && age < p.config.TTL {
+		return entry.rate, nil
+	}
+
+	if found && p.config.ServeStale && age < p.config.TTL+p.config.MaxStale {
+		p.refresh(key, source, target)
+
+		return entry.rate, nil
+	}
+
+	select {
+	case result := <-p.refresh(key, source, target):
+		if result.Err != nil {
+			return currency.Rate{}, result.Err
+		}
+
+		return result.Val.(currency.Rate), nil
+	case <-ctx.Done():
+		return currency.Rate{}, ctx.Err()
+	}
+}
+
+// refresh asks the upstream provider for a rate, joining the call already in
+// flight for the same pair if there is one.
+func (p *CachedRateProvider) refresh(key string, source string, target string) <-chan singleflight.Result {
+	return p.group.DoChan(key, func() (interface{}, error) {
+		if !p.breaker.allow() {
+			return nil, fmt.Errorf("%w: the circuit breaker is open", currency.ErrUnavailable)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), p.config.Timeout)
+		defer cancel()
+
+		rate, err := p.provider.GetRate(ctx, source, target)
+
+		// An unknown currency or a missing quote is a valid answer, it does not
+		// mean the upstream provider is failing.
+		if errors.Is(err, currency.ErrUnknownCurrency) || errors.Is(err, currency.ErrMissingQuote) {
+			p.breaker.record(nil)
+
+			return nil, err
+		}
+
+		p.breaker.record(err)
+
+		if err != nil {
+			if !errors.Is(err, currency.ErrMalformedPayload) && !errors.Is(err, currency.ErrUnavailable) {
+				err = fmt.Errorf("%w: %v", currency.ErrUnavailable, err)
+			}
+
+			return nil, err
+		}
+
+		p.mutex.Lock()
+		p.entries[key] = cachedRate{rate: rate, fetchedAt: p.now()}
+		p.mutex.Unlock()
+
+		return rate, nil
+	})
+}
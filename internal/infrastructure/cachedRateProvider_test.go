@@ -0,0 +1,184 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+	"github.com/stretchr/testify/assert"
+)
+
+type testClock struct {
+	current time.Time
+}
+
+func (c *testClock) now() time.Time {
+	return c.current
+}
+
+func newTestCachedRateProvider(upstream currency.ExchangeRateProvider, config RateCacheConfig) (*CachedRateProvider, *testClock) {
+	clock := &testClock{current: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)}
+	provider := NewCachedRateProvider(upstream, config)
+	provider.now = clock.now
+	provider.breaker.now = clock.now
+
+	return provider, clock
+}
+
+func Test_CachedRateProvider_Serves_Fresh_Rate_From_Cache(t *testing.T) {
+	// Arrange
+	upstream := NewInMemoryRateProvider()
+	upstream.SetRate("EUR", "PEN", 4)
+	provider, clock := newTestCachedRateProvider(upstream, RateCacheConfig{TTL: time.Minute})
+	ctx := context.Background()
+
+	// Act
+	first, _ := provider.GetRate(ctx, "EUR", "PEN")
+	clock.current = clock.current.Add(30 * time.Second)
+	second, err := provider.GetRate(ctx, "EUR", "PEN")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, upstream.Calls())
+}
+
+func Test_CachedRateProvider_Refreshes_Expired_Rate(t *testing.T) {
+	// Arrange
+	upstream := NewInMemoryRateProvider()
+	upstream.SetRate("EUR", "PEN", 4)
+	provider, clock := newTestCachedRateProvider(upstream, RateCacheConfig{TTL: time.Minute})
+	ctx := context.Background()
+
+	// Act
+	provider.GetRate(ctx, "EUR", "PEN")
+	upstream.SetRate("EUR", "PEN", 5)
+	clock.current = clock.current.Add(2 * time.Minute)
+	rate, err := provider.GetRate(ctx, "EUR", "PEN")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(5).Equal(rate.Value))
+	assert.Equal(t, 2, upstream.Calls())
+}
+
+func Test_CachedRateProvider_Serves_Stale_Rate_When_Upstream_Fails(t *testing.T) {
+	// Arrange
+	upstream := NewInMemoryRateProvider()
+	upstream.SetRate("EUR", "PEN", 4)
+	provider, clock := newTestCachedRateProvider(upstream, RateCacheConfig{TTL: time.Minute, ServeStale: true, MaxStale: time.Hour})
+	ctx := context.Background()
+
+	// Act
+	provider.GetRate(ctx, "EUR", "PEN")
+	upstream.Err = errors.New("upstream down")
+	clock.current = clock.current.Add(2 * time.Minute)
+	rate, err := provider.GetRate(ctx, "EUR", "PEN")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(4).Equal(rate.Value))
+}
+
+func Test_CachedRateProvider_Fails_When_Stale_Rate_Is_Too_Old(t *testing.T) {
+	// Arrange
+	upstream := NewInMemoryRateProvider()
+	upstream.SetRate("EUR", "PEN", 4)
+	provider, clock := newTestCachedRateProvider(upstream, RateCacheConfig{TTL: time.Minute, ServeStale: true, MaxStale: time.Hour})
+	ctx := context.Background()
+
+	// Act
+	provider.GetRate(ctx, "EUR", "PEN")
+	upstream.Err = errors.New("upstream down")
+	clock.current = clock.current.Add(2 * time.Hour)
+	_, err := provider.GetRate(ctx, "EUR", "PEN")
+
+	// Assert
+	assert.ErrorIs(t, err, currency.ErrUnavailable)
+}
+
+type blockingRateProvider struct {
+	release chan struct{}
+	mutex   sync.Mutex
+	calls   int
+}
+
+func (p *blockingRateProvider) GetRate(ctx context.Context, source string, target string) (currency.Rate, error) {
+	p.mutex.Lock()
+	p.calls++
+	p.mutex.Unlock()
+
+	<-p.release
+
+	return currency.Rate{Source: source, Target: target, Value: decimal.NewFromInt(4)}, nil
+}
+
+func Test_CachedRateProvider_Single_Refresh_In_Flight(t *testing.T) {
+	// Arrange
+	upstream := &blockingRateProvider{release: make(chan struct{})}
+	provider, _ := newTestCachedRateProvider(upstream, RateCacheConfig{TTL: time.Minute})
+	ctx := context.Background()
+	wg := sync.WaitGroup{}
+
+	// Act
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			provider.GetRate(ctx, "EUR", "PEN")
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(upstream.release)
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, 1, upstream.calls)
+}
+
+func Test_CachedRateProvider_Circuit_Breaker(t *testing.T) {
+	// Arrange
+	upstream := NewInMemoryRateProvider()
+	upstream.SetRate("EUR", "PEN", 4)
+	upstream.Err = errors.New("upstream down")
+	provider, clock := newTestCachedRateProvider(upstream, RateCacheConfig{FailureThreshold: 2, Cooldown: time.Minute})
+	ctx := context.Background()
+
+	// Act
+	provider.GetRate(ctx, "EUR", "PEN")
+	provider.GetRate(ctx, "EUR", "PEN")
+	_, openErr := provider.GetRate(ctx, "EUR", "PEN")
+	callsWhileOpen := upstream.Calls()
+
+	upstream.Err = nil
+	clock.current = clock.current.Add(2 * time.Minute)
+	rate, err := provider.GetRate(ctx, "EUR", "PEN")
+
+	// Assert
+	assert.ErrorIs(t, openErr, currency.ErrUnavailable)
+	assert.Equal(t, 2, callsWhileOpen)
+	assert.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(4).Equal(rate.Value))
+}
+
+func Test_CachedRateProvider_Missing_Quote_Does_Not_Open_Breaker(t *testing.T) {
+	// Arrange
+	upstream := NewInMemoryRateProvider()
+	provider, _ := newTestCachedRateProvider(upstream, RateCacheConfig{FailureThreshold: 1, Cooldown: time.Minute})
+	ctx := context.Background()
+
+	// Act
+	_, first := provider.GetRate(ctx, "EUR", "PEN")
+	upstream.SetRate("EUR", "PEN", 4)
+	rate, err := provider.GetRate(ctx, "EUR", "PEN")
+
+	// Assert
+	assert.ErrorIs(t, first, currency.ErrMissingQuote)
+	assert.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(4).Equal(rate.Value))
+}
@@ -0,0 +1,30 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// decodeConfigFile reads the JSON or YAML file at path into v, choosing the
+// format by the extension.
+func decodeConfigFile(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return json.Unmarshal(data, v)
+	case ".yaml", ".yml":
+		return yaml.Unmarshal(data, v)
+	default:
+		return fmt.Errorf("unsupported file %s, use .json, .yaml or .yml", path)
+	}
+}
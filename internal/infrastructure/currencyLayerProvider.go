@@ -0,0 +1,124 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+)
+
+// CurrencyLayerProvider reads live rates from the currencylayer API, which
+// quotes every currency against USD.
+type CurrencyLayerProvider struct {
+	BaseAddress string
+	AccessKey   string
+	client      *http.Client
+}
+
+// currencyLayerInvalidCurrencies is the error code currencylayer answers with
+// when one of the requested currencies is not supported.
+const currencyLayerInvalidCurrencies = 202
+
+type currencyLayerDTO struct {
+	Success   bool                       `json:"success"`
+	Timestamp int64                      `json:"timestamp"`
+	Source    string                     `json:"source"`
+	Quotes    map[string]decimal.Decimal `json:"quotes"`
+	Error     *struct {
+		Code int    `json:"code"`
+		Info string `json:"info"`
+	} `json:"error"`
+}
+
+func NewCurrencyLayerProvider(baseAddress string, accessKey string) CurrencyLayerProvider {
+	return CurrencyLayerProvider{
+		BaseAddress: baseAddress,
+		AccessKey:   accessKey,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p CurrencyLayerProvider) GetRate(ctx context.Context, source string, target string) (currency.Rate, error) {
+	query := url.Values{}
+	query.Set("access_key", p.AccessKey)
+	query.Set("currencies", source+","+target)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.BaseAddress+"live?"+query.Encode(), nil)
+
+	if err != nil {
+		return currency.Rate{}, err
+	}
+
+	res, err := p.client.Do(req)
+
+	if err != nil {
+		return currency.Rate{}, fmt.Errorf("%w: %v", currency.ErrUnavailable, err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return currency.Rate{}, fmt.Errorf("%w: currencylayer responded with status %d", currency.ErrUnavailable, res.StatusCode)
+	}
+
+	dto := currencyLayerDTO{}
+
+	if err := json.NewDecoder(res.Body).Decode(&dto); err != nil {
+		return currency.Rate{}, fmt.Errorf("%w: %v", currency.ErrMalformedPayload, err)
+	}
+
+	if !dto.Success {
+		if dto.Error == nil {
+			return currency.Rate{}, fmt.Errorf("%w: currencylayer request was not successful", currency.ErrUnavailable)
+		}
+
+		if dto.Error.Code == currencyLayerInvalidCurrencies {
+			return currency.Rate{}, fmt.Errorf("%w: %s", currency.ErrUnknownCurrency, dto.Error.Info)
+		}
+
+		return currency.Rate{}, fmt.Errorf("%w: currencylayer error %d: %s", currency.ErrUnavailable, dto.Error.Code, dto.Error.Info)
+	}
+
+	if dto.Source == "" {
+		return currency.Rate{}, fmt.Errorf("%w: currencylayer response has no source", currency.ErrMalformedPayload)
+	}
+
+	sourceQuote, err := dto.quote(source)
+
+	if err != nil {
+		return currency.Rate{}, err
+	}
+
+	targetQuote, err := dto.quote(target)
+
+	if err != nil {
+		return currency.Rate{}, err
+	}
+
+	return currency.Rate{
+		Source:    source,
+		Target:    target,
+		Value:     targetQuote.Div(sourceQuote, currency.RateScale),
+		Timestamp: time.Unix(dto.Timestamp, 0).UTC(),
+		Provider:  "currencylayer",
+	}, nil
+}
+
+func (dto currencyLayerDTO) quote(code string) (decimal.Decimal, error) {
+	if code == dto.Source {
+		return decimal.NewFromInt(1), nil
+	}
+
+	value, ok := dto.Quotes[dto.Source+code]
+
+	if !ok || value.Sign() <= 0 {
+		return decimal.Decimal{}, fmt.Errorf("%w: currencylayer has no quote for %s", currency.ErrMissingQuote, code)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,82 @@
+package infrastructure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CurrencyLayerProvider_GetRate(t *testing.T) {
+	// Arrange
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Query().Get("currencies")
+		w.Write([]byte(`{"success":true,"timestamp":1648771200,"source":"USD","quotes":{"USDEUR":0.5,"USDPEN":4}}`))
+	}))
+	defer server.Close()
+
+	provider := NewCurrencyLayerProvider(server.URL+"/", "key")
+
+	// Act
+	rate, err := provider.GetRate(context.Background(), "EUR", "PEN")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR,PEN", requested)
+	assert.True(t, decimal.NewFromInt(8).Equal(rate.Value))
+	assert.Equal(t, "currencylayer", rate.Provider)
+	assert.Equal(t, int64(1648771200), rate.Timestamp.Unix())
+}
+
+func Test_CurrencyLayerProvider_GetRate_Source_Currency(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":true,"timestamp":1648771200,"source":"USD","quotes":{"USDPEN":4}}`))
+	}))
+	defer server.Close()
+
+	provider := NewCurrencyLayerProvider(server.URL+"/", "key")
+
+	// Act
+	rate, err := provider.GetRate(context.Background(), "USD", "PEN")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(4).Equal(rate.Value))
+}
+
+func Test_CurrencyLayerProvider_GetRate_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{name: "api error", status: http.StatusOK, body: `{"success":false,"error":{"code":101,"info":"invalid key"}}`, want: currency.ErrUnavailable},
+		{name: "invalid currency", status: http.StatusOK, body: `{"success":false,"error":{"code":202,"info":"invalid currency codes"}}`, want: currency.ErrUnknownCurrency},
+		{name: "missing quote", status: http.StatusOK, body: `{"success":true,"source":"USD","quotes":{"USDEUR":0.5}}`, want: currency.ErrMissingQuote},
+		{name: "malformed payload", status: http.StatusOK, body: `<html>`, want: currency.ErrMalformedPayload},
+		{name: "missing source", status: http.StatusOK, body: `{"success":true,"quotes":{}}`, want: currency.ErrMalformedPayload},
+		{name: "server error", status: http.StatusBadGateway, body: ``, want: currency.ErrUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			provider := NewCurrencyLayerProvider(server.URL+"/", "key")
+
+			_, err := provider.GetRate(context.Background(), "EUR", "PEN")
+
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
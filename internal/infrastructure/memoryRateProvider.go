@@ -0,0 +1,69 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+)
+
+// InMemoryRateProvider is a fake provider for tests and local runs. Rates are
+// set pair by pair, and Err makes every lookup fail.
+type InMemoryRateProvider struct {
+	Err error
+
+	mutex sync.Mutex
+	rates map[string]decimal.Decimal
+	calls int
+}
+
+func NewInMemoryRateProvider() *InMemoryRateProvider {
+	return &InMemoryRateProvider{rates: map[string]decimal.Decimal{}}
+}
+
+func (p *InMemoryRateProvider) SetRate(source string, target string, value float64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.rates[source+target] = decimal.NewFromFloat(value)
+}
+
+// Calls returns how many lookups reached the provider.
+func (p *InMemoryRateProvider) Calls() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.calls
+}
+
+func (p *InMemoryRateProvider) GetRate(ctx context.Context, source string, target string) (currency.Rate, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.calls++
+
+	if p.Err != nil {
+		return currency.Rate{}, p.Err
+	}
+
+	value, ok := p.rates[source+target]
+
+	if source == target {
+		value, ok = decimal.NewFromInt(1), true
+	}
+
+	if !ok {
+		return currency.Rate{}, fmt.Errorf("%w: no rate from %s to %s", currency.ErrMissingQuote, source, target)
+	}
+
+	return currency.Rate{
+		Source:    source,
+		Target:    target,
+		Value:     value,
+		Timestamp: time.Now().UTC(),
+		Provider:  "memory",
+	}, nil
+}
@@ -0,0 +1,103 @@
+package infrastructure
+
+import (
+	"fmt"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/pricing"
+)
+
+type pricingRulesFile struct {
+	Rules []pricingRuleDTO `json:"rules" yaml:"rules"`
+}
+
+type pricingRuleDTO struct {
+	BeerId   int64            `json:"beerId" yaml:"beerId"`
+	Brewery  string           `json:"brewery" yaml:"brewery"`
+	Country  string           `json:"country" yaml:"country"`
+	BoxSizes []int64          `json:"boxSizes" yaml:"boxSizes"`
+	Tiers    []pricingTierDTO `json:"tiers" yaml:"tiers"`
+}
+
+type pricingTierDTO struct {
+	Name        string  `json:"name" yaml:"name"`
+	MinQuantity int64   `json:"minQuantity" yaml:"minQuantity"`
+	Percentage  float64 `json:"percentage" yaml:"percentage"`
+	Amount      float64 `json:"amount" yaml:"amount"`
+}
+
+// LoadPricingRules reads the box sizes and discount tiers from a JSON or YAML
+// file such as:
+//
+//	rules:
+//	  - boxSizes: [6, 12, 24]
+//	    tiers:
+//	      - name: 12 pack
+//	        minQuantity: 12
+//	        percentage: 5
+//	  - brewery: Backus
+//	    tiers:
+//	      - name: Backus 24 pack
+//	        minQuantity: 24
+//	        amount: 5
+func LoadPricingRules(path string) (pricing.Rules, error) {
+	file := pricingRulesFile{}
+
+	if err := decodeConfigFile(path, &file); err != nil {
+		return nil, err
+	}
+
+	rules := pricing.Rules{}
+
+	for i, dto := range file.Rules {
+		rule, err := dto.toRule()
+
+		if err != nil {
+			return nil, fmt.Errorf("pricing rule %d: %w", i+1, err)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func (dto pricingRuleDTO) toRule() (pricing.Rule, error) {
+	rule := pricing.Rule{
+		Scope: pricing.Scope{
+			BeerId:  dto.BeerId,
+			Brewery: dto.Brewery,
+			Country: dto.Country,
+		},
+		BoxSizes: dto.BoxSizes,
+	}
+
+	for _, size := range dto.BoxSizes {
+		if size <= 0 {
+			return pricing.Rule{}, fmt.Errorf("box size %d must be positive", size)
+		}
+	}
+
+	for _, tier := range dto.Tiers {
+		if tier.MinQuantity <= 0 {
+			return pricing.Rule{}, fmt.Errorf("tier %q needs a positive minQuantity", tier.Name)
+		}
+
+		if (tier.Percentage == 0) == (tier.Amount == 0) {
+			return pricing.Rule{}, fmt.Errorf("tier %q needs either a percentage or an amount", tier.Name)
+		}
+
+		if tier.Percentage < 0 || tier.Percentage > 100 || tier.Amount < 0 {
+			return pricing.Rule{}, fmt.Errorf("tier %q has an invalid discount", tier.Name)
+		}
+
+		rule.Tiers = append(rule.Tiers, pricing.Tier{
+			Name:        tier.Name,
+			MinQuantity: tier.MinQuantity,
+			Percentage:  decimal.NewFromFloat(tier.Percentage),
+			Amount:      decimal.NewFromFloat(tier.Amount),
+		})
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,68 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LoadPricingRules_Yaml(t *testing.T) {
+	// Arrange
+	path := writeTestFile(t, "pricing.yaml", `
+rules:
+  - boxSizes: [6, 12, 24]
+    tiers:
+      - name: 24 pack
+        minQuantity: 24
+        percentage: 7.5
+  - brewery: Backus
+    tiers:
+      - name: Backus
+        minQuantity: 12
+        amount: 2.25
+`)
+
+	// Act
+	rules, err := LoadPricingRules(path)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, []int64{6, 12, 24}, rules[0].BoxSizes)
+	assert.Equal(t, "7.5", rules[0].Tiers[0].Percentage.String())
+	assert.Equal(t, pricing.Scope{Brewery: "Backus"}, rules[1].Scope)
+	assert.Equal(t, "2.25", rules[1].Tiers[0].Amount.String())
+}
+
+func Test_LoadPricingRules_Json(t *testing.T) {
+	// Arrange
+	path := writeTestFile(t, "pricing.json", `{"rules":[{"beerId":3,"boxSizes":[4]}]}`)
+
+	// Act
+	rules, err := LoadPricingRules(path)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{4}, rules.BoxSizes(pricing.Product{BeerId: 3}))
+}
+
+func Test_LoadPricingRules_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "negative box size", content: `{"rules":[{"boxSizes":[-6]}]}`},
+		{name: "missing min quantity", content: `{"rules":[{"tiers":[{"name":"a","percentage":5}]}]}`},
+		{name: "percentage and amount", content: `{"rules":[{"tiers":[{"name":"a","minQuantity":6,"percentage":5,"amount":1}]}]}`},
+		{name: "no discount", content: `{"rules":[{"tiers":[{"name":"a","minQuantity":6}]}]}`},
+		{name: "percentage over 100", content: `{"rules":[{"tiers":[{"name":"a","minQuantity":6,"percentage":101}]}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadPricingRules(writeTestFile(t, "pricing.json", tt.content))
+
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+)
+
+// StaticRateProvider serves rates from a fixed table in which every currency
+// is quoted against a single base currency.
+type StaticRateProvider struct {
+	Base      string
+	Rates     map[string]float64
+	Timestamp time.Time
+}
+
+type staticRateTable struct {
+	Base      string             `json:"base" yaml:"base"`
+	Timestamp time.Time          `json:"timestamp" yaml:"timestamp"`
+	Rates     map[string]float64 `json:"rates" yaml:"rates"`
+}
+
+// LoadStaticRateProvider reads a rate table from a JSON or YAML file such as:
+//
+//	base: USD
+//	timestamp: 2022-04-01T00:00:00Z
+//	rates:
+//	  EUR: 0.9
+//	  PEN: 3.7
+func LoadStaticRateProvider(path string) (StaticRateProvider, error) {
+	table := staticRateTable{}
+
+	if err := decodeConfigFile(path, &table); err != nil {
+		return StaticRateProvider{}, err
+	}
+
+	if table.Base == "" {
+		return StaticRateProvider{}, fmt.Errorf("rate file %s has no base currency", path)
+	}
+
+	return StaticRateProvider{
+		Base:      table.Base,
+		Rates:     table.Rates,
+		Timestamp: table.Timestamp,
+	}, nil
+}
+
+func (p StaticRateProvider) GetRate(ctx context.Context, source string, target string) (currency.Rate, error) {
+	sourceQuote, err := p.quote(source)
+
+	if err != nil {
+		return currency.Rate{}, err
+	}
+
+	targetQuote, err := p.quote(target)
+
+	if err != nil {
+		return currency.Rate{}, err
+	}
+
+	return currency.Rate{
+		Source:    source,
+		Target:    target,
+		Value:     targetQuote.Div(sourceQuote, currency.RateScale),
+		Timestamp: p.Timestamp,
+		Provider:  "static",
+	}, nil
+}
+
+func (p StaticRateProvider) quote(code string) (decimal.Decimal, error) {
+	if code == p.Base {
+		return decimal.NewFromInt(1), nil
+	}
+
+	value, ok := p.Rates[code]
+
+	if !ok || value <= 0 {
+		return decimal.Decimal{}, fmt.Errorf("%w: the rate table has no quote for %s", currency.ErrMissingQuote, code)
+	}
+
+	return decimal.NewFromFloat(value), nil
+}
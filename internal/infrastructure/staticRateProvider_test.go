@@ -0,0 +1,86 @@
+package infrastructure
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestFile(t *testing.T, name string, content string) string {
+	dir, err := ioutil.TempDir("", "infrastructure")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, name)
+
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func Test_LoadStaticRateProvider_Yaml(t *testing.T) {
+	// Arrange
+	path := writeTestFile(t, "rates.yaml", "base: USD\nrates:\n  EUR: 0.5\n  PEN: 4\n")
+
+	// Act
+	provider, err := LoadStaticRateProvider(path)
+	rate, rateErr := provider.GetRate(context.Background(), "EUR", "PEN")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, rateErr)
+	assert.True(t, decimal.NewFromInt(8).Equal(rate.Value))
+	assert.Equal(t, "static", rate.Provider)
+}
+
+func Test_LoadStaticRateProvider_Json(t *testing.T) {
+	// Arrange
+	path := writeTestFile(t, "rates.json", `{"base":"USD","rates":{"PEN":4}}`)
+
+	// Act
+	provider, err := LoadStaticRateProvider(path)
+	rate, rateErr := provider.GetRate(context.Background(), "PEN", "USD")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, rateErr)
+	assert.True(t, decimal.MustParse("0.25").Equal(rate.Value))
+}
+
+func Test_LoadStaticRateProvider_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{name: "unsupported extension", file: "rates.txt", content: "base: USD"},
+		{name: "missing base", file: "rates.json", content: `{"rates":{"PEN":4}}`},
+		{name: "invalid content", file: "rates.yaml", content: "base: [USD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadStaticRateProvider(writeTestFile(t, tt.file, tt.content))
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_StaticRateProvider_Unknown_Currency(t *testing.T) {
+	provider := StaticRateProvider{Base: "USD", Rates: map[string]float64{"PEN": 4}}
+
+	_, err := provider.GetRate(context.Background(), "PEN", "EUR")
+
+	assert.Error(t, err)
+}
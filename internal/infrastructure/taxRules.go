@@ -0,0 +1,101 @@
+package infrastructure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/tax"
+)
+
+type taxRulesFile struct {
+	Destinations map[string][]taxLineDTO `json:"destinations" yaml:"destinations"`
+}
+
+type taxLineDTO struct {
+	Name       string  `json:"name" yaml:"name"`
+	Kind       string  `json:"kind" yaml:"kind"`
+	Percentage float64 `json:"percentage" yaml:"percentage"`
+	PerUnit    float64 `json:"perUnit" yaml:"perUnit"`
+	Currency   string  `json:"currency" yaml:"currency"`
+	MinAbv     float64 `json:"minAbv" yaml:"minAbv"`
+	MaxAbv     float64 `json:"maxAbv" yaml:"maxAbv"`
+}
+
+// LoadTaxRules reads the tax lines of every destination from a JSON or YAML
+// file such as:
+//
+//	destinations:
+//	  PE:
+//	    - name: ISC
+//	      kind: excise
+//	      perUnit: 1.5
+//	      currency: PEN
+//	      minAbv: 0.5
+//	    - name: IGV
+//	      kind: vat
+//	      percentage: 18
+func LoadTaxRules(path string) (tax.Rules, error) {
+	file := taxRulesFile{}
+
+	if err := decodeConfigFile(path, &file); err != nil {
+		return nil, err
+	}
+
+	rules := tax.Rules{}
+
+	for destination, dtos := range file.Destinations {
+		lines := []tax.Line{}
+
+		for _, dto := range dtos {
+			line, err := dto.toLine()
+
+			if err != nil {
+				return nil, fmt.Errorf("destination %s: %w", destination, err)
+			}
+
+			lines = append(lines, line)
+		}
+
+		rules[strings.ToUpper(destination)] = lines
+	}
+
+	return rules, nil
+}
+
+func (dto taxLineDTO) toLine() (tax.Line, error) {
+	kind := tax.Kind(strings.ToLower(dto.Kind))
+
+	if kind != tax.KindExcise && kind != tax.KindVat {
+		return tax.Line{}, fmt.Errorf("tax %q has kind %q, use excise or vat", dto.Name, dto.Kind)
+	}
+
+	if dto.Percentage < 0 || dto.PerUnit < 0 || dto.MinAbv < 0 || dto.MaxAbv < 0 {
+		return tax.Line{}, fmt.Errorf("tax %q has a negative value", dto.Name)
+	}
+
+	if kind == tax.KindVat && (dto.Percentage == 0 || dto.PerUnit != 0) {
+		return tax.Line{}, fmt.Errorf("vat %q needs a percentage", dto.Name)
+	}
+
+	if (dto.Percentage == 0) == (dto.PerUnit == 0) {
+		return tax.Line{}, fmt.Errorf("excise %q needs either a percentage or a perUnit amount", dto.Name)
+	}
+
+	code := currency.Normalize(dto.Currency)
+
+	if dto.PerUnit != 0 && !currency.IsKnown(code) {
+		return tax.Line{}, fmt.Errorf("excise %q has an unknown currency %q", dto.Name, dto.Currency)
+	}
+
+	return tax.Line{
+		Name:       dto.Name,
+		Kind:       kind,
+		Percentage: decimal.NewFromFloat(dto.Percentage),
+		PerUnit:    decimal.NewFromFloat(dto.PerUnit),
+		Currency:   code,
+		MinAbv:     decimal.NewFromFloat(dto.MinAbv),
+		MaxAbv:     decimal.NewFromFloat(dto.MaxAbv),
+	}, nil
+}
@@ -0,0 +1,57 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LoadTaxRules_Yaml(t *testing.T) {
+	// Arrange
+	path := writeTestFile(t, "taxes.yaml", `
+destinations:
+  pe:
+    - name: ISC
+      kind: excise
+      perUnit: 1.5
+      currency: pen
+      minAbv: 0.5
+    - name: IGV
+      kind: vat
+      percentage: 18
+`)
+
+	// Act
+	rules, err := LoadTaxRules(path)
+	lines, ok := rules.Lines("PE")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Len(t, lines, 2)
+	assert.Equal(t, tax.KindExcise, lines[0].Kind)
+	assert.Equal(t, "1.5", lines[0].PerUnit.String())
+	assert.Equal(t, "PEN", lines[0].Currency)
+	assert.Equal(t, "18", lines[1].Percentage.String())
+}
+
+func Test_LoadTaxRules_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "unknown kind", content: `{"destinations":{"PE":[{"name":"a","kind":"stamp","percentage":1}]}}`},
+		{name: "vat per unit", content: `{"destinations":{"PE":[{"name":"a","kind":"vat","perUnit":1,"currency":"PEN"}]}}`},
+		{name: "excise without amount", content: `{"destinations":{"PE":[{"name":"a","kind":"excise"}]}}`},
+		{name: "unknown currency", content: `{"destinations":{"PE":[{"name":"a","kind":"excise","perUnit":1,"currency":"XYZ"}]}}`},
+		{name: "negative", content: `{"destinations":{"PE":[{"name":"a","kind":"vat","percentage":-1}]}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadTaxRules(writeTestFile(t, "taxes.json", tt.content))
+
+			assert.Error(t, err)
+		})
+	}
+}
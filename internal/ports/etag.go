@@ -0,0 +1,71 @@
+package ports
+
+import (
+	"strconv"
+	"strings"
+)
+
+// formatETag returns the strong entity tag of a document version.
+func formatETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// parseETags reads the versions listed in an If-Match header. It returns nil
+// when the header is empty or "*", which places no condition on the version,
+// and an empty list when no tag is a version of ours. If-Match uses the strong
+// comparison of RFC 7232, so weak tags never match.
+func parseETags(header string) []int64 {
+	return etagVersions(header, false)
+}
+
+// etagVersions reads the versions listed in a conditional header, also the
+// ones of weak tags when weak is set.
+func etagVersions(header string, weak bool) []int64 {
+	header = strings.TrimSpace(header)
+
+	if header == "" || header == "*" {
+		return nil
+	}
+
+	versions := []int64{}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+
+		if strings.HasPrefix(tag, "W/") {
+			if !weak {
+				continue
+			}
+
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+
+		value, err := strconv.Unquote(tag)
+
+		if err != nil {
+			continue
+		}
+
+		if version, err := strconv.ParseInt(value, 10, 64); err == nil {
+			versions = append(versions, version)
+		}
+	}
+
+	return versions
+}
+
+// matchesETag reports whether an If-None-Match header matches the version,
+// with the weak comparison of RFC 7232.
+func matchesETag(header string, version int64) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+
+	for _, v := range etagVersions(header, true) {
+		if v == version {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseETags(t *testing.T) {
+	assert.Nil(t, parseETags(""))
+	assert.Nil(t, parseETags("*"))
+	assert.Equal(t, []int64{3, 5}, parseETags(`"3", "5"`))
+	assert.Equal(t, []int64{5}, parseETags(`W/"3", "5"`))
+	assert.Equal(t, []int64{}, parseETags(`W/"3"`))
+}
+
+func Test_MatchesETag(t *testing.T) {
+	assert.True(t, matchesETag(`"3"`, 3))
+	assert.True(t, matchesETag(`W/"3"`, 3))
+	assert.True(t, matchesETag("*", 3))
+	assert.False(t, matchesETag(`W/"4", "5"`, 3))
+}
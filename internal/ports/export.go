@@ -0,0 +1,178 @@
+package ports
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/internal/app/command"
+	"github.com/juanmaabanto/go-ms-beers/internal/ports/response"
+	"github.com/labstack/echo/v4"
+)
+
+// Formats of GET /beers/export and their media types.
+const (
+	exportCSV    = "csv"
+	exportNDJSON = "ndjson"
+	exportJSON   = "json"
+)
+
+var exportMediaTypes = map[string]string{
+	exportCSV:    "text/csv",
+	exportNDJSON: "application/x-ndjson",
+	exportJSON:   echo.MIMEApplicationJSON,
+}
+
+// exportFlushEvery is the number of beers written between flushes, so the
+// client receives the export as it is read.
+const exportFlushEvery = 100
+
+// exportColumns are the columns of a CSV export. They are the ones read by
+// POST /beers/import, so an export can be imported again.
+var exportColumns = []string{"id", "name", "brewery", "country", "price", "currency", "abv", "version"}
+
+// exportFormat picks the format from the format parameter or else from the
+// first media type of the Accept header it knows. JSON is the default.
+func exportFormat(c echo.Context) string {
+	if format := c.QueryParam("format"); format != "" {
+		if _, ok := exportMediaTypes[format]; !ok {
+			panic(errors.NewBadRequestError("The format must be csv, ndjson or json."))
+		}
+
+		return format
+	}
+
+	for _, accepted := range strings.Split(c.Request().Header.Get(echo.HeaderAccept), ",") {
+		mediaType := strings.TrimSpace(strings.Split(accepted, ";")[0])
+
+		switch mediaType {
+		case "text/csv":
+			return exportCSV
+		case "application/x-ndjson", "application/ndjson":
+			return exportNDJSON
+		case echo.MIMEApplicationJSON:
+			return exportJSON
+		}
+	}
+
+	return exportJSON
+}
+
+// beerEncoder writes the beers of an export as they come.
+type beerEncoder interface {
+	begin() error
+	encode(item response.BeerResponse) error
+	// flush sends what the encoder buffers to the underlying writer.
+	flush() error
+	end() error
+}
+
+func newBeerEncoder(format string, w io.Writer) beerEncoder {
+	switch format {
+	case exportCSV:
+		return &csvBeerEncoder{writer: csv.NewWriter(w)}
+	case exportNDJSON:
+		return &ndjsonBeerEncoder{encoder: json.NewEncoder(w)}
+	default:
+		return &jsonBeerEncoder{w: w, encoder: json.NewEncoder(w)}
+	}
+}
+
+type csvBeerEncoder struct {
+	writer *csv.Writer
+}
+
+func (e *csvBeerEncoder) begin() error {
+	return e.writer.Write(exportColumns)
+}
+
+func (e *csvBeerEncoder) encode(item response.BeerResponse) error {
+	return e.writer.Write([]string{
+		strconv.FormatInt(item.Id, 10),
+		csvText(item.Name),
+		csvText(item.Brewery),
+		csvText(item.Country),
+		item.Price.String(),
+		csvText(item.Currency),
+		item.Abv.String(),
+		strconv.FormatInt(item.Version, 10),
+	})
+}
+
+// csvText prefixes with a quote the text that a spreadsheet would run as a
+// formula. POST /beers/import removes the quote again.
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune(command.FormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+func (e *csvBeerEncoder) flush() error {
+	e.writer.Flush()
+
+	return e.writer.Error()
+}
+
+func (e *csvBeerEncoder) end() error {
+	return e.flush()
+}
+
+type ndjsonBeerEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonBeerEncoder) begin() error {
+	return nil
+}
+
+func (e *ndjsonBeerEncoder) encode(item response.BeerResponse) error {
+	return e.encoder.Encode(item)
+}
+
+func (e *ndjsonBeerEncoder) flush() error {
+	return nil
+}
+
+func (e *ndjsonBeerEncoder) end() error {
+	return nil
+}
+
+// jsonBeerEncoder writes a single array, one beer per line.
+type jsonBeerEncoder struct {
+	w       io.Writer
+	encoder *json.Encoder
+	count   int
+}
+
+func (e *jsonBeerEncoder) begin() error {
+	_, err := io.WriteString(e.w, "[")
+
+	return err
+}
+
+func (e *jsonBeerEncoder) encode(item response.BeerResponse) error {
+	if e.count > 0 {
+		if _, err := io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+
+	e.count++
+
+	return e.encoder.Encode(item)
+}
+
+func (e *jsonBeerEncoder) flush() error {
+	return nil
+}
+
+func (e *jsonBeerEncoder) end() error {
+	_, err := io.WriteString(e.w, "]\n")
+
+	return err
+}
@@ -0,0 +1,40 @@
+package ports
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/internal/app/command"
+	"github.com/juanmaabanto/go-ms-beers/internal/ports/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CSVBeerEncoder_Neutralises_Formulas(t *testing.T) {
+	// Arrange
+	out := bytes.Buffer{}
+	encoder := newBeerEncoder(exportCSV, &out)
+	item := response.BeerResponse{
+		Id:       1,
+		Name:     `=HYPERLINK("http://example.com","Pilsen")`,
+		Brewery:  "@Backus",
+		Country:  "PE",
+		Price:    decimal.MustParse("-3.50"),
+		Currency: "PEN",
+	}
+
+	// Act
+	encoder.begin()
+	encoder.encode(item)
+	err := encoder.end()
+
+	source, _ := command.NewBeerSource(command.FormatCSV, strings.NewReader(out.String()), nil)
+	_, imported, _ := source.Next()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), `"'=HYPERLINK(""http://example.com"",""Pilsen"")",'@Backus,PE,-3.50,`)
+	assert.Equal(t, item.Name, imported.Name)
+	assert.Equal(t, item.Brewery, imported.Brewery)
+}
@@ -0,0 +1,35 @@
+package ports
+
+import (
+	"encoding/json"
+)
+
+// selectFields keeps only the requested JSON fields of value, or the whole
+// value when none are requested. The handlers have already checked the names.
+func selectFields(value interface{}, fields []string) interface{} {
+	if len(fields) == 0 {
+		return value
+	}
+
+	data, err := json.Marshal(value)
+
+	if err != nil {
+		panic(err)
+	}
+
+	all := map[string]json.RawMessage{}
+
+	if err := json.Unmarshal(data, &all); err != nil {
+		panic(err)
+	}
+
+	selected := map[string]json.RawMessage{}
+
+	for _, field := range fields {
+		if raw, ok := all[field]; ok {
+			selected[field] = raw
+		}
+	}
+
+	return selected
+}
@@ -0,0 +1,69 @@
+package ports
+
+import (
+	errorsN "errors"
+	"strings"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+	"github.com/juanmaabanto/go-ms-beers/common/errors"
+	"github.com/juanmaabanto/go-ms-beers/internal/app/query"
+	"github.com/labstack/echo/v4"
+)
+
+// decimalRangeMessage explains why a number is rejected with
+// decimal.ErrOutOfRange.
+const decimalRangeMessage = "The number must have at most 34 significant digits and an exponent between -6176 and 6111."
+
+// beerFilter reads the filter of GET /beers from the query string. Fields
+// accept several values separated by commas, as in country=PE,CL.
+func beerFilter(c echo.Context) query.BeerFilter {
+	errs := map[string]string{}
+	filter := query.BeerFilter{
+		Name:       c.QueryParam("name"),
+		Search:     c.QueryParam("search"),
+		Breweries:  splitValues(c.QueryParam("brewery")),
+		Countries:  splitValues(c.QueryParam("country")),
+		Currencies: splitValues(c.QueryParam("currency")),
+	}
+
+	filter.MinPrice = parsePrice(c.QueryParam("minPrice"), "minPrice", errs)
+	filter.MaxPrice = parsePrice(c.QueryParam("maxPrice"), "maxPrice", errs)
+
+	if len(errs) > 0 {
+		panic(errors.NewValidationError(errs))
+	}
+
+	return filter
+}
+
+func splitValues(param string) []string {
+	values := []string{}
+
+	for _, value := range strings.Split(param, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func parsePrice(param string, name string, errs map[string]string) *decimal.Decimal {
+	if param == "" {
+		return nil
+	}
+
+	value, err := decimal.Parse(param)
+
+	if errorsN.Is(err, decimal.ErrOutOfRange) {
+		errs[name] = decimalRangeMessage
+		return nil
+	}
+
+	if err != nil {
+		errs[name] = "The price must be a number."
+		return nil
+	}
+
+	return &value
+}
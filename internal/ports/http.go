@@ -1,26 +1,55 @@
 package ports
 
 import (
+	"crypto/subtle"
+	errorsN "errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"os"
 	"strconv"
+	"strings"
 
 	"github.com/go-playground/validator"
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
 	"github.com/juanmaabanto/go-ms-beers/common/errors"
 	"github.com/juanmaabanto/go-ms-beers/common/responses"
 	"github.com/juanmaabanto/go-ms-beers/internal/app"
 	"github.com/juanmaabanto/go-ms-beers/internal/app/command"
 	"github.com/juanmaabanto/go-ms-beers/internal/app/query"
+	"github.com/juanmaabanto/go-ms-beers/internal/ports/response"
+	"github.com/juanmaabanto/go-ms-beers/internal/validations"
 	"github.com/labstack/echo/v4"
 )
 
+// defaultPageSize is used when GET /beers gets no pageSize, and
+// defaultMaxPageSize when MAX_PAGE_SIZE is not set.
+const (
+	defaultPageSize    = 50
+	defaultMaxPageSize = 100
+)
+
+// maxReportedRows bounds the failed rows listed in the response of an import,
+// the others are only counted.
+const maxReportedRows = 1000
+
 type HttpServer struct {
-	app app.Application
+	app         app.Application
+	adminKey    string
+	maxPageSize int64
 }
 
 func NewHttpServer(application app.Application) HttpServer {
+	maxPageSize, err := strconv.ParseInt(os.Getenv("MAX_PAGE_SIZE"), 10, 64)
+
+	if err != nil || maxPageSize <= 0 {
+		maxPageSize = defaultMaxPageSize
+	}
+
 	return HttpServer{
-		app: application,
+		app:         application,
+		adminKey:    os.Getenv("ADMIN_API_KEY"),
+		maxPageSize: maxPageSize,
 	}
 }
 
@@ -40,7 +69,7 @@ func (h HttpServer) AddBeer(c echo.Context) error {
 	item := command.CreateBeer{}
 
 	if err := c.Bind(&item); err != nil {
-		panic(err)
+		panic(bindError(err))
 	}
 
 	if err := c.Validate(item); err != nil {
@@ -59,13 +88,252 @@ func (h HttpServer) AddBeer(c echo.Context) error {
 	return c.JSON(http.StatusCreated, id)
 }
 
+// CreateBeers godoc
+// @Summary Create several beers at once.
+// @Description Every beer is validated and created on its own and reported in items. An ordered batch stops at the first beer that fails.
+// @Tags Beers
+// @Accept json
+// @Produce json
+// @Param command body command.CreateBeers true "Beers to be created."
+// @Success 200 {object} response.BatchResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 422 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /beers:batch [post]
+func (h HttpServer) CreateBeers(c echo.Context) error {
+	batch := command.CreateBeers{}
+
+	if err := c.Bind(&batch); err != nil {
+		panic(bindError(err))
+	}
+
+	results, err := h.app.Commands.CreateBeers.Handle(c.Request().Context(), batch)
+
+	if err != nil {
+		panic(err)
+	}
+
+	result := response.BatchResponse{Items: []response.BatchItemResponse{}}
+
+	for _, item := range results {
+		if item.Status == command.StatusCreated {
+			result.Created++
+		} else {
+			result.Failed++
+		}
+
+		result.Items = append(result.Items, batchItemResponse(item))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func batchItemResponse(item command.CreateBeersResult) response.BatchItemResponse {
+	result := response.BatchItemResponse{Index: item.Index, Id: item.Id, Status: item.Status}
+
+	switch item.Status {
+	case command.StatusCreated:
+	case command.StatusInvalid:
+		result.Message = "The beer is not valid."
+
+		if validationErrors, ok := item.Err.(validator.ValidationErrors); ok {
+			result.Errors = Simple(validationErrors)
+		}
+	case command.StatusConflict:
+		result.Message = item.Err.Error()
+	case command.StatusSkipped:
+		result.Message = "The beer was not tried because an earlier one failed."
+	default:
+		result.Message = "The beer could not be created."
+	}
+
+	return result
+}
+
+// ExportBeers godoc
+// @Summary Export every beer matching the filters.
+// @Description The beers are streamed as they are read. The format is taken from the format parameter or the Accept header, JSON by default.
+// @Tags Beers
+// @Produce json
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string  false  "csv, ndjson or json"
+// @Param name query string  false  "text to search in the names, taken literally"
+// @Param search query string  false  "how the name is matched: contains (default), prefix, or text to rank by relevance"
+// @Param brewery query string  false  "comma separated breweries"
+// @Param country query string  false  "comma separated countries"
+// @Param currency query string  false  "comma separated ISO 4217 currency codes"
+// @Param minPrice query number  false  "minimum price"
+// @Param maxPrice query number  false  "maximum price"
+// @Param sort query string  false  "comma separated fields among id, name, brewery, country, price, currency and abv, a leading - sorts descending"
+// @Success 200 {array} response.BeerResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 422 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /beers/export [get]
+func (h HttpServer) ExportBeers(c echo.Context) error {
+	format := exportFormat(c)
+	export := query.ExportBeers{
+		BeerFilter: beerFilter(c),
+		Sort:       c.QueryParam("sort"),
+	}
+
+	res := c.Response()
+	encoder := newBeerEncoder(format, res)
+	started := false
+	written := 0
+
+	// The status is sent with the first beer, so the errors found before it
+	// still get their own status.
+	start := func() error {
+		res.Header().Set(echo.HeaderContentType, exportMediaTypes[format])
+		res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"beers.%s\"", format))
+		res.WriteHeader(http.StatusOK)
+		started = true
+
+		return encoder.begin()
+	}
+
+	err := h.app.Queries.ExportBeers.Handle(c.Request().Context(), export, func(item response.BeerResponse) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+
+		if err := encoder.encode(item); err != nil {
+			return err
+		}
+
+		if written++; written%exportFlushEvery == 0 {
+			if err := encoder.flush(); err != nil {
+				return err
+			}
+
+			res.Flush()
+		}
+
+		return nil
+	})
+
+	if err != nil && !started {
+		panic(err)
+	}
+
+	// Once streaming the status cannot change, the export is cut short.
+	if err != nil {
+		return err
+	}
+
+	if !started {
+		if err := start(); err != nil {
+			return err
+		}
+	}
+
+	return encoder.end()
+}
+
+// ImportBeers godoc
+// @Summary Import beers from a CSV or NDJSON file.
+// @Description The file is the request body and is read as it arrives. CSV files name their columns in the first row.
+// @Tags Beers
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param format query string  false  "csv or ndjson, taken from the Content-Type by default"
+// @Param map query string  false  "comma separated column=field pairs renaming the CSV columns, as in Precio=price"
+// @Param mode query string  false  "insert (default) only creates beers, upsert also replaces the existing ones"
+// @Param dryRun query bool  false  "validate and report without writing"
+// @Success 200 {object} response.ImportResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 422 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /beers/import [post]
+func (h HttpServer) ImportBeers(c echo.Context) error {
+	format := c.QueryParam("format")
+
+	if format == "" {
+		format = importFormat(c.Request().Header.Get(echo.HeaderContentType))
+	}
+
+	dryRun := false
+
+	if param := c.QueryParam("dryRun"); param != "" {
+		value, err := strconv.ParseBool(param)
+
+		if err != nil {
+			panic(errors.NewBadRequestError("The dryRun must be true or false."))
+		}
+
+		dryRun = value
+	}
+
+	mapping, err := command.ParseColumnMapping(c.QueryParam("map"))
+
+	if err != nil {
+		panic(err)
+	}
+
+	source, err := command.NewBeerSource(format, c.Request().Body, mapping)
+
+	if err != nil {
+		panic(err)
+	}
+
+	result := response.ImportResponse{Errors: []response.ImportRowResponse{}}
+
+	report, err := h.app.Commands.ImportBeers.Handle(c.Request().Context(), command.ImportBeers{
+		Source: source,
+		Mode:   c.QueryParam("mode"),
+		DryRun: dryRun,
+		OnError: func(row command.ImportRowError) error {
+			if len(result.Errors) == maxReportedRows {
+				result.Truncated = true
+				return nil
+			}
+
+			result.Errors = append(result.Errors, response.ImportRowResponse(row))
+
+			return nil
+		},
+	})
+
+	if err != nil {
+		panic(err)
+	}
+
+	result.Rows = report.Rows
+	result.Created = report.Created
+	result.Updated = report.Updated
+	result.Failed = report.Failed
+	result.DryRun = report.DryRun
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// importFormat picks the format of an import from its media type.
+func importFormat(contentType string) string {
+	switch strings.TrimSpace(strings.Split(contentType, ";")[0]) {
+	case "text/csv":
+		return command.FormatCSV
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return command.FormatNDJSON
+	default:
+		return ""
+	}
+}
+
 // GetBeer godoc
 // @Summary Get a beer by Id.
 // @Tags Beers
 // @Accept json
 // @Produce json
 // @Param id path int64  true  "Beer Id"
+// @Param fields query string  false  "comma separated fields of the beer to return, all of them by default"
+// @Param If-None-Match header string  false  "ETag of the cached beer"
 // @Success 200 {object} response.BeerResponse
+// @Success 304
 // @Failure 400 {object} responses.ErrorResponse
 // @Failure 404 {object} responses.ErrorResponse
 // @Failure 500 {object} responses.ErrorResponse
@@ -78,7 +346,8 @@ func (h HttpServer) GetBeer(c echo.Context) error {
 		panic(err)
 	}
 
-	item := query.GetBeerById{Id: beerId}
+	fields := splitValues(c.QueryParam("fields"))
+	item := query.GetBeerById{Id: beerId, Fields: fields}
 
 	result, err := h.app.Queries.GetBeerById.Handle(c.Request().Context(), item)
 
@@ -86,7 +355,13 @@ func (h HttpServer) GetBeer(c echo.Context) error {
 		panic(err)
 	}
 
-	return c.JSON(http.StatusOK, result)
+	c.Response().Header().Set("ETag", formatETag(result.Version))
+
+	if matchesETag(c.Request().Header.Get("If-None-Match"), result.Version) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.JSON(http.StatusOK, selectFields(result, fields))
 }
 
 // ListBeer godoc
@@ -94,42 +369,120 @@ func (h HttpServer) GetBeer(c echo.Context) error {
 // @Tags Beers
 // @Accept json
 // @Produce json
-// @Param name query string  false  "word to search"
-// @Param pageSize query int  false  "Number of results per page"
-// @Param start query string  false  "Page number"
+// @Param name query string  false  "text to search in the names, taken literally"
+// @Param search query string  false  "how the name is matched: contains (default), prefix, or text to rank by relevance"
+// @Param brewery query string  false  "comma separated breweries"
+// @Param country query string  false  "comma separated countries"
+// @Param currency query string  false  "comma separated ISO 4217 currency codes"
+// @Param minPrice query number  false  "minimum price"
+// @Param maxPrice query number  false  "maximum price"
+// @Param sort query string  false  "comma separated fields among id, name, brewery, country, price, currency and abv, a leading - sorts descending"
+// @Param pageSize query int  false  "Number of results per page, 50 by default and capped by MAX_PAGE_SIZE"
+// @Param start query int  false  "Number of beers to skip"
+// @Param cursor query string  false  "nextCursor or prevCursor of a previous page, replaces start"
+// @Param fields query string  false  "comma separated fields of the beers to return, all of them by default"
 // @Success 200 {object} responses.PaginatedResponse
+// @Header 200 {string} Link "RFC 8288 links to the self, first, prev, next and last pages"
 // @Failure 400 {object} responses.ErrorResponse
+// @Failure 422 {object} responses.ErrorResponse
 // @Failure 500 {object} responses.ErrorResponse
 // @Router /beers [get]
 func (h HttpServer) ListBeer(c echo.Context) error {
-	pageSize, err := strconv.Atoi(c.QueryParam("pageSize"))
+	pageSize := intParam(c, "pageSize", defaultPageSize)
+
+	if pageSize > h.maxPageSize {
+		pageSize = h.maxPageSize
+	}
+
+	list := query.ListBeers{
+		BeerFilter: beerFilter(c),
+		Sort:       c.QueryParam("sort"),
+		Fields:     splitValues(c.QueryParam("fields")),
+		Cursor:     c.QueryParam("cursor"),
+		Start:      intParam(c, "start", 0),
+		PageSize:   pageSize,
+	}
+
+	page, err := h.app.Queries.ListBeers.Handle(c.Request().Context(), list)
 
 	if err != nil {
-		pageSize = 50
+		panic(err)
 	}
 
-	start, err := strconv.Atoi(c.QueryParam("start"))
+	links := pageLinks(c.Request().URL, list, page)
+
+	c.Response().Header().Set("Link", linkHeader(links))
+
+	data := []interface{}{}
+
+	for _, item := range page.Items {
+		data = append(data, selectFields(item, list.Fields))
+	}
+
+	return c.JSON(http.StatusOK, responses.PaginatedResponse{
+		Start:      list.Start,
+		PageSize:   list.PageSize,
+		Total:      page.Total,
+		Data:       data,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+		Links:      links,
+	})
+}
+
+// intParam reads an integer query parameter, or defaultValue when it is
+// missing.
+func intParam(c echo.Context, name string, defaultValue int64) int64 {
+	param := c.QueryParam(name)
+
+	if param == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseInt(param, 10, 64)
 
 	if err != nil {
-		start = 0
+		panic(errors.NewBadRequestError(fmt.Sprintf("The %s must be a number.", name)))
 	}
 
-	total, items, err := h.app.Queries.ListBeers.Handle(c.Request().Context(), query.ListBeers{
-		Name:     c.QueryParam("name"),
-		Start:    int64(start),
-		PageSize: int64(pageSize),
+	return value
+}
+
+// GetBeerFacets godoc
+// @Summary Count the beers of a search by country, brewery, currency and price.
+// @Tags Beers
+// @Accept json
+// @Produce json
+// @Param name query string  false  "text to search in the names, taken literally"
+// @Param search query string  false  "how the name is matched: contains (default), prefix, or text"
+// @Param brewery query string  false  "comma separated breweries"
+// @Param country query string  false  "comma separated countries"
+// @Param currency query string  false  "comma separated ISO 4217 currency codes"
+// @Param minPrice query number  false  "minimum price"
+// @Param maxPrice query number  false  "maximum price"
+// @Param priceInterval query number  false  "width of the price buckets, 10 by default and at least 0.01"
+// @Success 200 {object} response.FacetsResponse
+// @Failure 422 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /beers/facets [get]
+func (h HttpServer) GetBeerFacets(c echo.Context) error {
+	errs := map[string]string{}
+	interval := parsePrice(c.QueryParam("priceInterval"), "priceInterval", errs)
+
+	if len(errs) > 0 {
+		panic(errors.NewValidationError(errs))
+	}
+
+	result, err := h.app.Queries.GetBeerFacets.Handle(c.Request().Context(), query.GetBeerFacets{
+		BeerFilter:    beerFilter(c),
+		PriceInterval: interval,
 	})
 
 	if err != nil {
 		panic(err)
 	}
 
-	return c.JSON(http.StatusOK, responses.PaginatedResponse{
-		Start:    int64(start),
-		PageSize: int64(pageSize),
-		Total:    total,
-		Data:     items,
-	})
+	return c.JSON(http.StatusOK, result)
 }
 
 // GetBoxPrice godoc
@@ -138,12 +491,16 @@ func (h HttpServer) ListBeer(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param id path int64  true    "search one beer for Id"
-// @Param currency query int  false  "money to pay"
-// @Param quantity query int  false  "quantity"
-// @Success 200 {object} responses.PaginatedResponse
+// @Param currency query string  false  "ISO 4217 currency code to pay in"
+// @Param quantity query int  false  "box size, 6 by default; sizes and discounts come from the pricing rules"
+// @Param destination query string  false  "ISO 3166 country the box is shipped to, adds its taxes"
+// @Success 200 {object} response.PriceResponse
 // @Failure 400 {object} responses.ErrorResponse
 // @Failure 404 {object} responses.ErrorResponse
+// @Failure 422 {object} responses.ErrorResponse
 // @Failure 500 {object} responses.ErrorResponse
+// @Failure 502 {object} responses.ErrorResponse
+// @Failure 503 {object} responses.ErrorResponse
 // @Router /beers/{beerId}/boxprice [get]
 func (h HttpServer) GetBoxPrice(c echo.Context) error {
 	var beerId int64
@@ -153,16 +510,23 @@ func (h HttpServer) GetBoxPrice(c echo.Context) error {
 		panic(err)
 	}
 
-	quantity, err := strconv.Atoi(c.QueryParam("quantity"))
+	quantity := 6
 
-	if err != nil {
-		quantity = 6
+	if param := c.QueryParam("quantity"); param != "" {
+		n, err := strconv.Atoi(param)
+
+		if err != nil {
+			panic(errors.NewBadRequestError("The quantity must be a number."))
+		}
+
+		quantity = n
 	}
 
 	result, err := h.app.Queries.GetBoxPrice.Handle(c.Request().Context(), query.GetBoxPrice{
-		Currency: c.QueryParam("currency"),
-		Id:       beerId,
-		Quantity: int64(quantity),
+		Currency:    c.QueryParam("currency"),
+		Id:          beerId,
+		Quantity:    int64(quantity),
+		Destination: c.QueryParam("destination"),
 	})
 
 	if err != nil {
@@ -172,16 +536,241 @@ func (h HttpServer) GetBoxPrice(c echo.Context) error {
 	return c.JSON(http.StatusOK, result)
 }
 
-func Simple(verr validator.ValidationErrors) map[string]string {
-	errs := make(map[string]string)
+// GetQuote godoc
+// @Summary Price a cart of beer boxes.
+// @Description Lines whose beer is missing or whose quantity is not a box size report an error and are left out of the total.
+// @Tags Quotes
+// @Accept json
+// @Produce json
+// @Param query body query.GetQuote true "Lines to price and currency to pay in."
+// @Success 200 {object} response.QuoteResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 422 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Failure 502 {object} responses.ErrorResponse
+// @Failure 503 {object} responses.ErrorResponse
+// @Router /quotes [post]
+func (h HttpServer) GetQuote(c echo.Context) error {
+	item := query.GetQuote{}
+
+	if err := c.Bind(&item); err != nil {
+		panic(bindError(err))
+	}
+
+	if err := c.Validate(item); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		panic(errors.NewValidationError(Simple(validationErrors)))
+	}
+
+	result, err := h.app.Queries.GetQuote.Handle(c.Request().Context(), item)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// UpdateBeer godoc
+// @Summary Replace an existing beer.
+// @Tags Beers
+// @Accept json
+// @Produce json
+// @Param id path int64  true  "Beer Id"
+// @Param If-Match header string  false  "ETag the beer must still have"
+// @Param command body command.UpdateBeer true "Object to be replaced."
+// @Success 204
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 409 {object} responses.ErrorResponse
+// @Failure 412 {object} responses.ErrorResponse
+// @Failure 422 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /beers/{beerId} [put]
+func (h HttpServer) UpdateBeer(c echo.Context) error {
+	var beerId int64
+	if n, err := strconv.Atoi(c.Param("beerId")); err == nil {
+		beerId = int64(n)
+	} else {
+		panic(err)
+	}
+
+	item := command.UpdateBeer{}
+
+	if err := c.Bind(&item); err != nil {
+		panic(bindError(err))
+	}
+
+	item.Id = beerId
+	item.Versions = parseETags(c.Request().Header.Get("If-Match"))
+
+	if err := c.Validate(item); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		panic(errors.NewValidationError(Simple(validationErrors)))
+	}
+
+	version, err := h.app.Commands.UpdateBeer.Handle(c.Request().Context(), item)
+
+	if err != nil {
+		panic(err)
+	}
+
+	c.Response().Header().Set("ETag", formatETag(version))
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PatchBeer godoc
+// @Summary Partially update a beer with a JSON Merge Patch document.
+// @Tags Beers
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path int64  true  "Beer Id"
+// @Param If-Match header string  false  "ETag the beer must still have"
+// @Param command body command.UpdateBeer true "Fields to be changed, null removes a field."
+// @Success 204
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 409 {object} responses.ErrorResponse
+// @Failure 412 {object} responses.ErrorResponse
+// @Failure 422 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /beers/{beerId} [patch]
+func (h HttpServer) PatchBeer(c echo.Context) error {
+	var beerId int64
+	if n, err := strconv.Atoi(c.Param("beerId")); err == nil {
+		beerId = int64(n)
+	} else {
+		panic(err)
+	}
+
+	patch, err := ioutil.ReadAll(c.Request().Body)
+
+	if err != nil {
+		panic(err)
+	}
+
+	version, err := h.app.Commands.PatchBeer.Handle(c.Request().Context(), command.PatchBeer{
+		Id:       beerId,
+		Versions: parseETags(c.Request().Header.Get("If-Match")),
+		Patch:    patch,
+	})
+
+	if validationErrors, ok := err.(validator.ValidationErrors); ok {
+		panic(errors.NewValidationError(Simple(validationErrors)))
+	}
+
+	if err != nil {
+		panic(err)
+	}
+
+	c.Response().Header().Set("ETag", formatETag(version))
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteBeer godoc
+// @Summary Delete a beer, it can be restored unless it is purged.
+// @Tags Beers
+// @Accept json
+// @Produce json
+// @Param id path int64  true  "Beer Id"
+// @Param purge query bool  false  "Remove the beer permanently, requires the X-Api-Key header"
+// @Param If-Match header string  false  "ETag the beer must still have"
+// @Success 204
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 409 {object} responses.ErrorResponse
+// @Failure 412 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /beers/{beerId} [delete]
+func (h HttpServer) DeleteBeer(c echo.Context) error {
+	var beerId int64
+	if n, err := strconv.Atoi(c.Param("beerId")); err == nil {
+		beerId = int64(n)
+	} else {
+		panic(err)
+	}
 
-	for _, f := range verr {
-		err := f.ActualTag()
-		if f.Param() != "" {
-			err = fmt.Sprintf("%s=%s", err, f.Param())
+	purge := false
+
+	if param := c.QueryParam("purge"); param != "" {
+		value, err := strconv.ParseBool(param)
+
+		if err != nil {
+			panic(errors.NewBadRequestError("The purge must be true or false."))
 		}
-		errs[f.Field()] = err
+
+		purge = value
+	}
+
+	if purge && !h.isAdmin(c) {
+		panic(errors.NewForbiddenError("Only administrators can purge a beer."))
 	}
 
-	return errs
+	err := h.app.Commands.DeleteBeer.Handle(c.Request().Context(), command.DeleteBeer{
+		Id:       beerId,
+		Versions: parseETags(c.Request().Header.Get("If-Match")),
+		Purge:    purge,
+	})
+
+	if err != nil {
+		panic(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RestoreBeer godoc
+// @Summary Restore a deleted beer.
+// @Tags Beers
+// @Accept json
+// @Produce json
+// @Param id path int64  true  "Beer Id"
+// @Success 204
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 409 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /beers/{beerId}/restore [post]
+func (h HttpServer) RestoreBeer(c echo.Context) error {
+	var beerId int64
+	if n, err := strconv.Atoi(c.Param("beerId")); err == nil {
+		beerId = int64(n)
+	} else {
+		panic(err)
+	}
+
+	err := h.app.Commands.RestoreBeer.Handle(c.Request().Context(), command.RestoreBeer{Id: beerId})
+
+	if err != nil {
+		panic(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h HttpServer) isAdmin(c echo.Context) bool {
+	key := c.Request().Header.Get("X-Api-Key")
+
+	if h.adminKey == "" || key == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(key), []byte(h.adminKey)) == 1
+}
+
+// bindError turns a number of the body that does not fit in a decimal into a
+// validation error, other errors are returned as they are.
+func bindError(err error) error {
+	if errorsN.Is(err, decimal.ErrOutOfRange) {
+		return errors.NewValidationError(map[string]string{"body": decimalRangeMessage})
+	}
+
+	return err
+}
+
+func Simple(verr validator.ValidationErrors) map[string]string {
+	return validations.Simple(verr)
 }
@@ -0,0 +1,93 @@
+package ports
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/juanmaabanto/go-ms-beers/common/responses"
+	"github.com/juanmaabanto/go-ms-beers/internal/app/query"
+)
+
+// pageLinks builds the links of a page of GET /beers from the request, so
+// they keep its filters and sort. Pages read with a cursor link to the
+// cursors around them, the others to offsets.
+func pageLinks(request *url.URL, list query.ListBeers, page query.BeerPage) responses.PageLinks {
+	links := responses.PageLinks{
+		First: pageURL(request, list.PageSize, "start", "0"),
+		Last:  pageURL(request, list.PageSize, "start", strconv.FormatInt(lastStart(page.Total, list.PageSize), 10)),
+	}
+
+	if list.Cursor != "" {
+		links.Self = pageURL(request, list.PageSize, "cursor", list.Cursor)
+
+		if page.PrevCursor != "" {
+			links.Prev = pageURL(request, list.PageSize, "cursor", page.PrevCursor)
+		}
+
+		if page.NextCursor != "" {
+			links.Next = pageURL(request, list.PageSize, "cursor", page.NextCursor)
+		}
+
+		return links
+	}
+
+	links.Self = pageURL(request, list.PageSize, "start", strconv.FormatInt(list.Start, 10))
+
+	if list.Start > 0 {
+		prev := list.Start - list.PageSize
+
+		if prev < 0 {
+			prev = 0
+		}
+
+		links.Prev = pageURL(request, list.PageSize, "start", strconv.FormatInt(prev, 10))
+	}
+
+	if next := list.Start + list.PageSize; next < page.Total {
+		links.Next = pageURL(request, list.PageSize, "start", strconv.FormatInt(next, 10))
+	}
+
+	return links
+}
+
+// linkHeader writes the links as an RFC 8288 Link header.
+func linkHeader(links responses.PageLinks) string {
+	values := []string{}
+
+	for _, link := range []struct{ rel, url string }{
+		{"self", links.Self},
+		{"first", links.First},
+		{"prev", links.Prev},
+		{"next", links.Next},
+		{"last", links.Last},
+	} {
+		if link.url != "" {
+			values = append(values, "<"+link.url+`>; rel="`+link.rel+`"`)
+		}
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// pageURL returns the request URL positioned with either start or cursor.
+func pageURL(request *url.URL, pageSize int64, key string, value string) string {
+	params := request.Query()
+
+	params.Del("start")
+	params.Del("cursor")
+	params.Set("pageSize", strconv.FormatInt(pageSize, 10))
+	params.Set(key, value)
+
+	return (&url.URL{Path: request.Path, RawQuery: params.Encode()}).String()
+}
+
+// lastStart returns the offset of the last page, the first one when there are
+// no beers.
+func lastStart(total int64, pageSize int64) int64 {
+	if total == 0 {
+		return 0
+	}
+
+	return (total - 1) / pageSize * pageSize
+}
@@ -0,0 +1,17 @@
+package response
+
+type BatchResponse struct {
+	Created int                 `json:"created"`
+	Failed  int                 `json:"failed"`
+	Items   []BatchItemResponse `json:"items"`
+}
+
+// BatchItemResponse tells what happened to the beer at Index of the batch:
+// created, conflict, invalid, skipped or failed.
+type BatchItemResponse struct {
+	Index   int               `json:"index"`
+	Id      int64             `json:"id"`
+	Status  string            `json:"status"`
+	Message string            `json:"message,omitempty"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
@@ -1,10 +1,14 @@
 package response
 
+import "github.com/juanmaabanto/go-ms-beers/common/decimal"
+
 type BeerResponse struct {
-	Id       int64   `json:"id"`
-	Name     string  `json:"name"`
-	Brewery  string  `json:"brewery"`
-	Country  string  `json:"country"`
-	Price    float64 `json:"price"`
-	Currency string  `json:"currency"`
+	Id       int64           `json:"id"`
+	Name     string          `json:"name"`
+	Brewery  string          `json:"brewery"`
+	Country  string          `json:"country"`
+	Price    decimal.Decimal `json:"price" swaggertype:"number"`
+	Currency string          `json:"currency"`
+	Abv      decimal.Decimal `json:"abv" swaggertype:"number"`
+	Version  int64           `json:"version"`
 }
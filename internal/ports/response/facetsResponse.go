@@ -0,0 +1,25 @@
+package response
+
+import "github.com/juanmaabanto/go-ms-beers/common/decimal"
+
+type FacetsResponse struct {
+	Total      int64                 `json:"total"`
+	Countries  []FacetBucketResponse `json:"countries"`
+	Breweries  []FacetBucketResponse `json:"breweries"`
+	Currencies []FacetBucketResponse `json:"currencies"`
+	Prices     []PriceBucketResponse `json:"prices"`
+}
+
+type FacetBucketResponse struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// PriceBucketResponse counts the beers priced from From, included, to To,
+// excluded, in Currency.
+type PriceBucketResponse struct {
+	Currency string          `json:"currency"`
+	From     decimal.Decimal `json:"from" swaggertype:"number"`
+	To       decimal.Decimal `json:"to" swaggertype:"number"`
+	Count    int64           `json:"count"`
+}
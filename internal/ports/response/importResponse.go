@@ -0,0 +1,22 @@
+package response
+
+type ImportResponse struct {
+	Rows    int                 `json:"rows"`
+	Created int                 `json:"created"`
+	Updated int                 `json:"updated"`
+	Failed  int                 `json:"failed"`
+	DryRun  bool                `json:"dryRun"`
+	Errors  []ImportRowResponse `json:"errors"`
+	// Truncated tells that more rows failed than the ones listed in Errors.
+	Truncated bool `json:"truncated"`
+}
+
+// ImportRowResponse tells why the row at Line was not imported: conflict,
+// invalid or failed.
+type ImportRowResponse struct {
+	Line    int               `json:"line"`
+	Id      int64             `json:"id,omitempty"`
+	Status  string            `json:"status"`
+	Message string            `json:"message"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
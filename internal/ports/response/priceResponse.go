@@ -1,5 +1,39 @@
 package response
 
+import (
+	"time"
+
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
+)
+
 type PriceResponse struct {
-	PriceTotal float64 `json:"priceTotal"`
+	PriceTotal         decimal.Decimal      `json:"priceTotal" swaggertype:"number"`
+	Currency           string               `json:"currency"`
+	Destination        string               `json:"destination,omitempty"`
+	UnitPrice          MoneyResponse        `json:"unitPrice"`
+	ExchangeRate       *RateResponse        `json:"exchangeRate,omitempty"`
+	ConvertedUnitPrice MoneyResponse        `json:"convertedUnitPrice"`
+	Quantity           int64                `json:"quantity"`
+	Subtotal           decimal.Decimal      `json:"subtotal" swaggertype:"number"`
+	Discounts          []AdjustmentResponse `json:"discounts"`
+	Taxes              []AdjustmentResponse `json:"taxes"`
+	Total              decimal.Decimal      `json:"total" swaggertype:"number"`
+}
+
+type MoneyResponse struct {
+	Amount   decimal.Decimal `json:"amount" swaggertype:"number"`
+	Currency string          `json:"currency"`
+}
+
+type RateResponse struct {
+	Source    string          `json:"source"`
+	Target    string          `json:"target"`
+	Value     decimal.Decimal `json:"value" swaggertype:"number"`
+	Timestamp time.Time       `json:"timestamp"`
+	Provider  string          `json:"provider"`
+}
+
+type AdjustmentResponse struct {
+	Name   string          `json:"name"`
+	Amount decimal.Decimal `json:"amount" swaggertype:"number"`
 }
@@ -0,0 +1,18 @@
+package response
+
+import "github.com/juanmaabanto/go-ms-beers/common/decimal"
+
+type QuoteResponse struct {
+	Currency    string              `json:"currency"`
+	Destination string              `json:"destination,omitempty"`
+	Lines       []QuoteLineResponse `json:"lines"`
+	Total       decimal.Decimal     `json:"total" swaggertype:"number"`
+}
+
+type QuoteLineResponse struct {
+	BeerId   int64            `json:"beerId"`
+	Quantity int64            `json:"quantity"`
+	Total    *decimal.Decimal `json:"total,omitempty" swaggertype:"number"`
+	Price    *PriceResponse   `json:"price,omitempty"`
+	Error    string           `json:"error,omitempty"`
+}
@@ -2,30 +2,181 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/juanmaabanto/go-ms-beers/common"
+	"github.com/juanmaabanto/go-ms-beers/common/cursor"
 	"github.com/juanmaabanto/go-ms-beers/common/database"
 	"github.com/juanmaabanto/go-ms-beers/internal/app"
 	"github.com/juanmaabanto/go-ms-beers/internal/app/command"
 	"github.com/juanmaabanto/go-ms-beers/internal/app/query"
-	"github.com/juanmaabanto/go-ms-beers/internal/domain/beer"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/currency"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/pricing"
+	"github.com/juanmaabanto/go-ms-beers/internal/domain/tax"
 	"github.com/juanmaabanto/go-ms-beers/internal/infrastructure"
+	"github.com/juanmaabanto/go-ms-beers/internal/validations"
 )
 
 func NewApplication(ctx context.Context) app.Application {
 	conn := database.NewMongoConnection(ctx, os.Getenv("MONGODB_NAME"), os.Getenv("MONGODB_URI"))
-	document := new(beer.Beer)
+	rates := newExchangeRateProvider()
+	rules := newPricingRules()
+	taxes := newTaxRules()
 
-	beerRepository := infrastructure.NewBeerRepository(conn, *document)
+	beerRepository := infrastructure.NewBeerRepository(conn)
+
+	if err := beerRepository.EnsureIndexes(ctx); err != nil {
+		panic(err)
+	}
 
 	return app.Application{
 		Commands: app.Commands{
-			CreateBeer: command.NewCreateBeerHandler(beerRepository),
+			CreateBeer:  command.NewCreateBeerHandler(beerRepository, newUnitOfWork(ctx, conn)),
+			CreateBeers: command.NewCreateBeersHandler(beerRepository, validations.NewValidationUtil()),
+			ImportBeers: command.NewImportBeersHandler(beerRepository, validations.NewValidationUtil()),
+			UpdateBeer:  command.NewUpdateBeerHandler(beerRepository),
+			PatchBeer:   command.NewPatchBeerHandler(beerRepository, validations.NewValidationUtil()),
+			DeleteBeer:  command.NewDeleteBeerHandler(beerRepository),
+			RestoreBeer: command.NewRestoreBeerHandler(beerRepository),
 		},
 		Queries: app.Queries{
-			GetBeerById: query.NewGetBeerByIdHandler(beerRepository),
-			ListBeers:   query.NewListBeersHandler(beerRepository),
-			GetBoxPrice: query.NewGetBoxPriceHandler(beerRepository),
+			GetBeerById:   query.NewGetBeerByIdHandler(beerRepository),
+			ListBeers:     query.NewListBeersHandler(beerRepository, newCursorCodec()),
+			ExportBeers:   query.NewExportBeersHandler(beerRepository),
+			GetBeerFacets: query.NewGetBeerFacetsHandler(beerRepository),
+			GetBoxPrice:   query.NewGetBoxPriceHandler(beerRepository, rates, rules, taxes),
+			GetQuote:      query.NewGetQuoteHandler(beerRepository, rates, rules, taxes),
 		},
 	}
 }
+
+func newExchangeRateProvider() currency.ExchangeRateProvider {
+	return infrastructure.NewCachedRateProvider(newUpstreamRateProvider(), infrastructure.RateCacheConfig{
+		TTL:              durationEnv("RATES_CACHE_TTL", time.Hour),
+		ServeStale:       boolEnv("RATES_SERVE_STALE", true),
+		MaxStale:         durationEnv("RATES_MAX_STALE", 24*time.Hour),
+		Timeout:          durationEnv("RATES_TIMEOUT", 10*time.Second),
+		FailureThreshold: intEnv("RATES_BREAKER_THRESHOLD", 5),
+		Cooldown:         durationEnv("RATES_BREAKER_COOLDOWN", 30*time.Second),
+	})
+}
+
+func newUpstreamRateProvider() currency.ExchangeRateProvider {
+	switch os.Getenv("RATES_PROVIDER") {
+	case "static":
+		provider, err := infrastructure.LoadStaticRateProvider(os.Getenv("RATES_FILE"))
+
+		if err != nil {
+			panic(err)
+		}
+
+		return provider
+	case "memory":
+		return infrastructure.NewInMemoryRateProvider()
+	default:
+		baseAddress := os.Getenv("CURRENCYLAYER_URL")
+
+		if baseAddress == "" {
+			baseAddress = "http://api.currencylayer.com/"
+		}
+
+		return infrastructure.NewCurrencyLayerProvider(baseAddress, os.Getenv("CURRENCYLAYER_ACCESS_KEY"))
+	}
+}
+
+func newPricingRules() pricing.Rules {
+	path := os.Getenv("PRICING_RULES_FILE")
+
+	if path == "" {
+		return pricing.DefaultRules()
+	}
+
+	rules, err := infrastructure.LoadPricingRules(path)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return rules
+}
+
+func newTaxRules() tax.Rules {
+	path := os.Getenv("TAX_RULES_FILE")
+
+	if path == "" {
+		return tax.Rules{}
+	}
+
+	rules, err := infrastructure.LoadTaxRules(path)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return rules
+}
+
+// newCursorCodec signs the page cursors with CURSOR_SECRET. Without it a random
+// secret is used, and cursors stop working when the service restarts.
+func newCursorCodec() cursor.Codec {
+	secret := []byte(os.Getenv("CURSOR_SECRET"))
+
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+
+		if _, err := rand.Read(secret); err != nil {
+			panic(err)
+		}
+	}
+
+	return cursor.NewCodec(secret)
+}
+
+// newUnitOfWork runs units of work in transactions when the server supports
+// them. Standalone servers get the no-op unit of work.
+func newUnitOfWork(ctx context.Context, conn database.MongoConnection) common.UnitOfWork {
+	supported, err := conn.SupportsTransactions(ctx)
+
+	if err != nil {
+		panic(err)
+	}
+
+	if !supported {
+		return common.NoopUnitOfWork{}
+	}
+
+	return common.NewMongoUnitOfWork(conn)
+}
+
+func boolEnv(name string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(name))
+
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func durationEnv(name string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(name))
+
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func intEnv(name string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
@@ -1,7 +1,11 @@
 package validations
 
 import (
+	"fmt"
+	"reflect"
+
 	"github.com/go-playground/validator"
+	"github.com/juanmaabanto/go-ms-beers/common/decimal"
 	"github.com/labstack/echo/v4"
 )
 
@@ -10,9 +14,33 @@ type ValidationUtil struct {
 }
 
 func NewValidationUtil() echo.Validator {
-	return &ValidationUtil{validator: validator.New()}
+	validate := validator.New()
+	validate.RegisterCustomTypeFunc(decimalValue, decimal.Decimal{})
+
+	return &ValidationUtil{validator: validate}
 }
 
 func (v *ValidationUtil) Validate(i interface{}) error {
 	return v.validator.Struct(i)
 }
+
+// decimalValue lets numeric tags such as required or gt validate decimals.
+func decimalValue(field reflect.Value) interface{} {
+	return field.Interface().(decimal.Decimal).Float64()
+}
+
+// Simple maps every invalid field to the tag it failed, with its parameter,
+// as in "max=30".
+func Simple(verr validator.ValidationErrors) map[string]string {
+	errs := make(map[string]string)
+
+	for _, f := range verr {
+		err := f.ActualTag()
+		if f.Param() != "" {
+			err = fmt.Sprintf("%s=%s", err, f.Param())
+		}
+		errs[f.Field()] = err
+	}
+
+	return errs
+}